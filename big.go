@@ -0,0 +1,59 @@
+package quickselect
+
+import "math/big"
+
+// The BigIntSlice type attaches the QuickSelect interface to an array of
+// *big.Ints. It implements Interface so that you can call QuickSelect(k) on
+// any BigIntSlice. Elements are compared with Cmp, and nil elements are
+// considered smaller than any other value.
+type BigIntSlice []*big.Int
+
+func (t BigIntSlice) Len() int {
+	return len(t)
+}
+
+func (t BigIntSlice) Less(i, j int) bool {
+	if t[i] == nil || t[j] == nil {
+		return t[i] == nil && t[j] != nil
+	}
+	return t[i].Cmp(t[j]) < 0
+}
+
+func (t BigIntSlice) Swap(i, j int) {
+	t[i], t[j] = t[j], t[i]
+}
+
+// QuickSelect(k) mutates the BigIntSlice so that the first k elements in the
+// BigIntSlice are the k smallest elements in the slice. This is a convenience
+// method for QuickSelect
+func (t BigIntSlice) QuickSelect(k int) error {
+	return QuickSelect(t, k)
+}
+
+// The BigFloatSlice type attaches the QuickSelect interface to an array of
+// *big.Floats. It implements Interface so that you can call QuickSelect(k) on
+// any BigFloatSlice. Elements are compared with Cmp, regardless of their
+// precision, and nil elements are considered smaller than any other value.
+type BigFloatSlice []*big.Float
+
+func (t BigFloatSlice) Len() int {
+	return len(t)
+}
+
+func (t BigFloatSlice) Less(i, j int) bool {
+	if t[i] == nil || t[j] == nil {
+		return t[i] == nil && t[j] != nil
+	}
+	return t[i].Cmp(t[j]) < 0
+}
+
+func (t BigFloatSlice) Swap(i, j int) {
+	t[i], t[j] = t[j], t[i]
+}
+
+// QuickSelect(k) mutates the BigFloatSlice so that the first k elements in the
+// BigFloatSlice are the k smallest elements in the slice. This is a
+// convenience method for QuickSelect
+func (t BigFloatSlice) QuickSelect(k int) error {
+	return QuickSelect(t, k)
+}
@@ -0,0 +1,44 @@
+package quickselect
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestBigIntSliceQuickSelect(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	negative, _ := new(big.Int).SetString("-98765432109876543210", 10)
+	data := BigIntSlice{huge, big.NewInt(7), nil, negative, big.NewInt(-3), big.NewInt(42)}
+
+	if err := data.QuickSelect(3); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+
+	expected := map[string]bool{"<nil>": true, negative.String(): true, "-3": true}
+	for _, elem := range data[:3] {
+		if !expected[elem.String()] {
+			t.Errorf("Expected smallest K elements to be nil, %s and -3, but got %v", negative, data[:3])
+			break
+		}
+	}
+}
+
+func TestBigFloatSliceQuickSelect(t *testing.T) {
+	third := new(big.Float).SetPrec(200).Quo(big.NewFloat(1), big.NewFloat(3))
+	quarter := big.NewFloat(0.25)
+	minusInf := big.NewFloat(math.Inf(-1))
+	data := BigFloatSlice{big.NewFloat(0.5), third, minusInf, nil, quarter, big.NewFloat(1)}
+
+	if err := data.QuickSelect(4); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+
+	expected := map[*big.Float]bool{nil: true, minusInf: true, quarter: true, third: true}
+	for _, elem := range data[:4] {
+		if !expected[elem] {
+			t.Errorf("Expected smallest K elements to be nil, -Inf, 0.25 and 1/3, but got %v", data[:4])
+			break
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package quickselect
+
+import "errors"
+
+// ErrComparisonBudget is returned by SelectBounded when the selection needs
+// more comparisons than it was allowed.
+var ErrComparisonBudget = errors.New("The selection exceeded its comparison budget")
+
+// budgetExceeded is the panic value a budgetData raises once its budget is
+// spent, which SelectBounded recovers from.
+type budgetExceeded struct{}
+
+// budgetData panics once more than budget calls have been made to Less.
+type budgetData struct {
+	Interface
+	budget *int
+}
+
+func (t budgetData) Less(i, j int) bool {
+	if *t.budget--; *t.budget < 0 {
+		panic(budgetExceeded{})
+	}
+	return t.Interface.Less(i, j)
+}
+
+/*
+SelectBounded works like QuickSelect, but gives up and returns
+ErrComparisonBudget as soon as the selection would call Less more than
+maxComparisons times. This caps the work done on adversarial inputs, and lets
+the caller fall back on a strategy of their own. Every comparison any strategy
+makes counts against the budget. If the selection is abandoned the data is left
+partially reordered.
+*/
+func SelectBounded(data Interface, k int, maxComparisons int) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(budgetExceeded); !ok {
+				panic(r)
+			}
+			err = ErrComparisonBudget
+		}
+	}()
+
+	budget := maxComparisons
+	return QuickSelect(budgetData{data, &budget}, k)
+}
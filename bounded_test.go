@@ -0,0 +1,43 @@
+package quickselect
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestSelectBounded(t *testing.T) {
+	array := randomInts(10000, 5000, 9)
+	sorted := append([]int(nil), array...)
+	sort.Ints(sorted)
+
+	data := append(IntSlice(nil), array...)
+	if err := SelectBounded(data, 5000, 100*len(data)); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElements(data[:5000], sorted[:5000]) {
+		t.Errorf("Wrong smallest 5000 elements")
+	}
+
+	data = append(IntSlice(nil), array...)
+	if err := SelectBounded(data, 5000, len(data)/2); err != ErrComparisonBudget {
+		t.Errorf("Expected error '%v', but got '%v'", ErrComparisonBudget, err)
+	}
+	sortedData := append([]int(nil), data...)
+	sort.Ints(sortedData)
+	if !hasSameElements(sortedData, sorted) {
+		t.Errorf("Expected the data to be a permutation of the original")
+	}
+
+	if err := SelectBounded(IntSlice{1, 2}, 3, 100); err == nil || err == ErrComparisonBudget {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestSelectBoundedRepanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("Expected the panic 'boom' to be passed on, but got '%v'", r)
+		}
+	}()
+	SelectBounded(&panickyLess{IntSlice: make(IntSlice, 100), calls: 10, value: "boom"}, 50, 1000)
+}
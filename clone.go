@@ -0,0 +1,40 @@
+package quickselect
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Cloneable is implemented by data that can copy itself. The clone must be
+// independent of the original, so that swapping elements of one never affects
+// the other.
+type Cloneable interface {
+	Clone() Interface
+}
+
+// ErrNotCloneable is returned by SelectNonDestructive for data that doesn't
+// implement Cloneable.
+var ErrNotCloneable = errors.New("The data doesn't implement Cloneable, so it can only be selected on in place with QuickSelect or Select")
+
+/*
+SelectNonDestructive clones the data and selects on the clone, leaving the data
+itself untouched. It returns the clone along with the bounds of the block
+holding the k smallest elements within it, which is clone[lo:hi], just like
+Select does. This brings non-mutating selection to any type implementing both
+Interface and Cloneable, not just the slices this package provides.
+
+An error is raised if the data doesn't implement Cloneable, or if k is outside
+of the range [0, data.Len()].
+*/
+func SelectNonDestructive(data Interface, k int) (clone Interface, lo, hi int, err error) {
+	cloneable, ok := data.(Cloneable)
+	if !ok {
+		return nil, 0, 0, fmt.Errorf("%w: got '%T'", ErrNotCloneable, data)
+	}
+
+	clone = cloneable.Clone()
+	if err := QuickSelect(clone, k); err != nil {
+		return nil, 0, 0, err
+	}
+	return clone, 0, k, nil
+}
@@ -0,0 +1,45 @@
+package quickselect
+
+import (
+	"errors"
+	"testing"
+)
+
+// cloneableInts is an IntSlice that can copy itself.
+type cloneableInts struct {
+	IntSlice
+}
+
+func (t cloneableInts) Clone() Interface {
+	return cloneableInts{append(IntSlice(nil), t.IntSlice...)}
+}
+
+func TestSelectNonDestructive(t *testing.T) {
+	data := cloneableInts{IntSlice{16, 29, -11, 25, 28, -14, 10, 4, 7, -27}}
+	original := append([]int(nil), data.IntSlice...)
+
+	clone, lo, hi, err := SelectNonDestructive(data, 4)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if lo != 0 || hi != 4 {
+		t.Errorf("Expected block [0,4), but got [%d,%d)", lo, hi)
+	}
+	selected := clone.(cloneableInts).IntSlice
+	if !hasSameElements(selected[lo:hi], []int{-27, -14, -11, 4}) {
+		t.Errorf("Expected smallest K elements to be '%v', but got '%v'", []int{-27, -14, -11, 4}, selected[lo:hi])
+	}
+	for i := range original {
+		if data.IntSlice[i] != original[i] {
+			t.Errorf("Expected data to be left untouched as '%v', but got '%v'", original, data.IntSlice)
+			break
+		}
+	}
+
+	if _, _, _, err := SelectNonDestructive(data, 11); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+	if _, _, _, err := SelectNonDestructive(IntSlice{3, 1, 2}, 1); !errors.Is(err, ErrNotCloneable) {
+		t.Errorf("Expected ErrNotCloneable for data without Clone, but got '%v'", err)
+	}
+}
@@ -0,0 +1,48 @@
+package quickselect
+
+import "math"
+
+/*
+Float64ClosestK returns a newly allocated slice holding the k elements of the
+data closest to the target, that is with the smallest |x - target|, leaving the
+data itself untouched. The returned elements are in no particular order.
+
+It selects on a copy of the data with the distance to the target as the key,
+so besides the copy that's returned it allocates one slice of n distances, and
+runs in O(n) time. An element equal to the target is at distance zero, even if
+both are infinite. NaNs, and any other element whose distance is undefined, are
+farther from the target than every number, infinities included, so they're
+only returned if there aren't k other elements in the data. An error is raised
+if k is outside of the range [0, len(data)].
+*/
+func Float64ClosestK(data []float64, target float64, k int) ([]float64, error) {
+	length := len(data)
+	if k < 0 || k > length {
+		return nil, outOfRange(k, length)
+	}
+
+	scratch := append([]float64(nil), data...)
+	distances := make([]float64, length)
+	for i, x := range scratch {
+		if x != target {
+			distances[i] = math.Abs(x - target)
+		}
+	}
+	err := QuickSelect(lessSwap{
+		n: length,
+		less: func(i, j int) bool {
+			if isNaN(distances[i]) {
+				return false
+			}
+			return isNaN(distances[j]) || distances[i] < distances[j]
+		},
+		swap: func(i, j int) {
+			distances[i], distances[j] = distances[j], distances[i]
+			scratch[i], scratch[j] = scratch[j], scratch[i]
+		},
+	}, k)
+	if err != nil {
+		return nil, err
+	}
+	return scratch[:k:k], nil
+}
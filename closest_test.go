@@ -0,0 +1,73 @@
+package quickselect
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFloat64ClosestK(t *testing.T) {
+	data := []float64{12.5, -3, 9.75, 10.5, math.NaN(), 40, 10, 7, -11}
+	original := append([]float64(nil), data...)
+
+	fixtures := []struct {
+		Target   float64
+		K        int
+		Expected []float64
+	}{
+		{10, 3, []float64{10, 10.5, 9.75}},
+		{10, 1, []float64{10}},
+		{-5, 2, []float64{-3, -11}},
+		{100, 2, []float64{40, 12.5}},
+		{0, 0, []float64{}},
+		{0, 8, []float64{12.5, -3, 9.75, 10.5, 40, 10, 7, -11}},
+	}
+
+	for _, fixture := range fixtures {
+		closest, err := Float64ClosestK(data, fixture.Target, fixture.K)
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if len(closest) != fixture.K || !hasSameElementsFloat64(closest, fixture.Expected) {
+			t.Errorf("Expected the %d elements closest to %g to be '%v', but got '%v'", fixture.K, fixture.Target, fixture.Expected, closest)
+		}
+	}
+
+	for i := range data {
+		if data[i] != original[i] && !(math.IsNaN(data[i]) && math.IsNaN(original[i])) {
+			t.Errorf("Expected data to be left untouched as '%v', but got '%v'", original, data)
+			break
+		}
+	}
+
+	if _, err := Float64ClosestK(data, 0, len(data)+1); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestFloat64ClosestKNonFinite(t *testing.T) {
+	inf := math.Inf(1)
+	data := []float64{1, inf, math.NaN(), -inf, 5}
+
+	fixtures := []struct {
+		Target   float64
+		K        int
+		Expected []float64
+	}{
+		{0, 4, []float64{1, 5, inf, -inf}},
+		{0, 2, []float64{1, 5}},
+		{inf, 1, []float64{inf}},
+		{inf, 4, []float64{inf, 1, 5, -inf}},
+		{-inf, 1, []float64{-inf}},
+		{3, 5, []float64{1, 5, inf, -inf, math.NaN()}},
+	}
+
+	for _, fixture := range fixtures {
+		closest, err := Float64ClosestK(data, fixture.Target, fixture.K)
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if len(closest) != fixture.K || !hasSameElementsFloat64(closest, fixture.Expected) {
+			t.Errorf("Expected the %d elements closest to %g to be '%v', but got '%v'", fixture.K, fixture.Target, fixture.Expected, closest)
+		}
+	}
+}
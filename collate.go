@@ -0,0 +1,30 @@
+package quickselect
+
+/*
+A Collator compares strings according to the rules of some locale, returning
+-1, 0 or +1 like strings.Compare. A *collate.Collator from
+golang.org/x/text/collate satisfies it, without this package having to depend
+on it.
+*/
+type Collator interface {
+	CompareString(a, b string) int
+}
+
+/*
+CollatedStringSelect mutates the data so that the first k elements in the
+string slice are the k smallest elements as ordered by the collator, rather
+than by their bytes like StringQuickSelect does. This is what human facing
+listings need, where accents and case should sort the way readers expect:
+
+	c := collate.New(language.French)
+	quickselect.CollatedStringSelect(names, 10, c)
+
+Only comparisons go through the collator, swaps don't. A *collate.Collator keeps
+internal buffers between comparisons, so it isn't safe for concurrent use: run
+concurrent selections with a collator each.
+*/
+func CollatedStringSelect(data []string, k int, c Collator) error {
+	return QuickSelectFunc(len(data), k,
+		func(i, j int) bool { return c.CompareString(data[i], data[j]) < 0 },
+		func(i, j int) { data[i], data[j] = data[j], data[i] })
+}
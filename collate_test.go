@@ -0,0 +1,34 @@
+package quickselect
+
+import (
+	"strings"
+	"testing"
+)
+
+// foldingCollator orders strings case insensitively, standing in for a
+// locale aware collator.
+type foldingCollator struct{}
+
+func (foldingCollator) CompareString(a, b string) int {
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+func TestCollatedStringSelect(t *testing.T) {
+	names := []string{"delta", "Bravo", "echo", "alpha", "Charlie", "foxtrot"}
+
+	if err := CollatedStringSelect(names, 3, foldingCollator{}); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+
+	expected := map[string]bool{"alpha": true, "Bravo": true, "Charlie": true}
+	for _, name := range names[:3] {
+		if !expected[name] {
+			t.Errorf("Expected smallest K elements to be alpha, Bravo and Charlie, but got %v", names[:3])
+			break
+		}
+	}
+
+	if err := CollatedStringSelect(names, 7, foldingCollator{}); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
@@ -0,0 +1,114 @@
+package quickselect
+
+import (
+	"math/bits"
+	"math/rand/v2"
+)
+
+/*
+Comparer can be implemented in addition to Interface by data for which a three
+way comparison is natural. Compare(i, j) must return a negative number if the
+element with index i is less than the one with index j, a positive number if it
+is greater, and zero if they're equal, consistently with Less.
+
+Partition, and so every selection built on it, tells the three groups apart
+with a single call to Compare per element where it would otherwise need up to
+two calls to Less, which pays off when comparisons are expensive.
+*/
+type Comparer interface {
+	Compare(i, j int) int
+}
+
+// cmpSwap attaches Interface and Comparer to a pair of functions.
+type cmpSwap struct {
+	n    int
+	cmp  func(i, j int) int
+	swap func(i, j int)
+}
+
+func (t cmpSwap) Len() int {
+	return t.n
+}
+
+func (t cmpSwap) Less(i, j int) bool {
+	return t.cmp(i, j) < 0
+}
+
+func (t cmpSwap) Compare(i, j int) int {
+	return t.cmp(i, j)
+}
+
+func (t cmpSwap) Swap(i, j int) {
+	t.swap(i, j)
+}
+
+/*
+SelectCmp works like QuickSelectFunc, but takes a three way comparison, which
+returns a negative number, zero or a positive number when the i-th element is
+less than, equal to or greater than the j-th one, like cmp.Compare does.
+
+Every round partitions three ways around a random pivot with one call to cmp
+per element, so runs of duplicates are dealt with in a single round and no
+element is compared twice to tell "equal" from "greater". After bits.Len(n)
+rounds that keep more than 7/8 of their range, pivots are picked with the
+median of medians instead, which bounds the running time to O(n).
+*/
+func SelectCmp(n, k int, cmp func(i, j int) int, swap func(i, j int)) error {
+	if k < 0 || k > n {
+		return outOfRange(k, n)
+	} else if k == 0 || k == n {
+		return nil
+	}
+
+	data := cmpSwap{n, cmp, swap}
+	low, high := 0, n-1
+	limit := bits.Len(uint(n))
+	for {
+		if low >= high {
+			return nil
+		} else if high-low <= PartitionThreshold {
+			insertionSort(data, low, high+1)
+			return nil
+		}
+
+		var pivotIndex int
+		if limit > 0 {
+			pivotIndex = rand.IntN(high+1-low) + low
+		} else {
+			pivotIndex = medianOfMedians(data, low, high)
+		}
+		size := high + 1 - low
+		lt, gt := Partition(data, low, high, pivotIndex)
+
+		if k < lt {
+			high = lt - 1
+		} else if k > gt {
+			low = gt + 1
+		} else {
+			return nil
+		}
+		if high+1-low > size-size/8 {
+			limit--
+		}
+	}
+}
+
+// Does the same as Partition, with a single call to Compare per element.
+func partitionCompare(data Interface, c Comparer, lo, hi, pivot int) (lt, gt int) {
+	data.Swap(lo, pivot)
+
+	lt, gt = lo, hi
+	for i := lo + 1; i <= gt; {
+		if order := c.Compare(i, lt); order < 0 {
+			data.Swap(i, lt)
+			lt++
+			i++
+		} else if order > 0 {
+			data.Swap(i, gt)
+			gt--
+		} else {
+			i++
+		}
+	}
+	return lt, gt
+}
@@ -0,0 +1,86 @@
+package quickselect
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"sort"
+	"testing"
+)
+
+func TestSelectCmp(t *testing.T) {
+	for _, distinct := range []int{1, 3, 100, 100000} {
+		array := make([]int, 100000)
+		for i := range array {
+			array[i] = rand.IntN(distinct)
+		}
+		sorted := append([]int(nil), array...)
+		sort.Ints(sorted)
+
+		for _, k := range []int{0, 1, 10, 50000, 99999, 100000} {
+			data := append([]int(nil), array...)
+			compares := 0
+			err := SelectCmp(len(data), k,
+				func(i, j int) int {
+					compares++
+					return cmp.Compare(data[i], data[j])
+				},
+				func(i, j int) { data[i], data[j] = data[j], data[i] })
+			if err != nil {
+				t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+			}
+			if !hasSameElements(data[:k], sorted[:k]) {
+				t.Errorf("Expected SelectCmp to find the smallest %d elements of %d distinct values", k, distinct)
+			}
+			if compares > 10*len(data) {
+				t.Errorf("Expected at most %d comparisons for k = %d, but got %d", 10*len(data), k, compares)
+			}
+		}
+	}
+
+	err := SelectCmp(3, 4, func(i, j int) int { return 0 }, func(i, j int) {})
+	if err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestPartitionComparer(t *testing.T) {
+	// Equal heavy data needs two calls to Less for most elements, but only
+	// one call to Compare.
+	array := make([]int, 10000)
+	for i := range array {
+		array[i] = rand.IntN(3)
+	}
+	withLess := append(IntSlice(nil), array...)
+	withCompare := append([]int(nil), array...)
+
+	lessCalls, compareCalls := 0, 0
+	lt, gt := Partition(lessSwap{
+		n: len(withLess),
+		less: func(i, j int) bool {
+			lessCalls++
+			return withLess[i] < withLess[j]
+		},
+		swap: withLess.Swap,
+	}, 0, len(withLess)-1, 0)
+	compareLt, compareGt := Partition(cmpSwap{
+		n: len(withCompare),
+		cmp: func(i, j int) int {
+			compareCalls++
+			return cmp.Compare(withCompare[i], withCompare[j])
+		},
+		swap: func(i, j int) { withCompare[i], withCompare[j] = withCompare[j], withCompare[i] },
+	}, 0, len(withCompare)-1, 0)
+
+	if lt != compareLt || gt != compareGt {
+		t.Errorf("Expected the groups to end at %d and %d with Compare, but got %d and %d", lt, gt, compareLt, compareGt)
+	}
+	for i := range withLess {
+		if withLess[i] != withCompare[i] {
+			t.Errorf("Expected Compare to partition just like Less")
+			break
+		}
+	}
+	if compareCalls != len(array)-1 || compareCalls >= lessCalls {
+		t.Errorf("Expected %d calls to Compare, fewer than the %d calls to Less, but got %d", len(array)-1, lessCalls, compareCalls)
+	}
+}
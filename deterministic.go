@@ -0,0 +1,99 @@
+package quickselect
+
+import "fmt"
+
+/*
+QuickSelectDeterministic swaps elements in the data provided so that the first
+k elements are the smallest k elements in the data, just like QuickSelect.
+
+Unlike QuickSelect it picks its pivots with the median of medians (BFPRT)
+algorithm, which guarantees a worst-case running time of O(n) regardless of the
+input. The price is a considerably larger constant factor, so this is only
+worth it when a pathological input must never slow things down.
+*/
+func QuickSelectDeterministic(data Interface, k int) error {
+	length := data.Len()
+	if k < 0 || k > length {
+		return outOfRange(k, length)
+	} else if k == 0 {
+		return nil
+	}
+
+	deterministicSelectionFinding(data, 0, length-1, k)
+	return nil
+}
+
+/*
+Helper function that does all of the work for QuickSelectDeterministic. It
+works like randomizedSelectionFinding, but takes the median of medians as its
+pivot and partitions three ways, so every round is guaranteed to discard a
+constant fraction of the range, even if it's full of duplicates.
+*/
+func deterministicSelectionFinding(data Interface, low, high, k int) {
+	for {
+		if low >= high {
+			return
+		} else if high-low <= PartitionThreshold {
+			insertionSort(data, low, high+1)
+			return
+		}
+
+		pivotIndex := medianOfMedians(data, low, high)
+		lt, gt := Partition(data, low, high, pivotIndex)
+
+		if k < lt {
+			high = lt - 1
+		} else if k > gt {
+			low = gt + 1
+		} else {
+			return
+		}
+	}
+}
+
+/*
+MedianOfMedians returns the index of an element in the range [lo, hi] of the
+data (both inclusive, like Partition) that's guaranteed to be a good pivot: at
+least about 30% of the range is no larger than it, and at least about 30% is no
+smaller, so it falls between the 30th and 70th percentile. It's the textbook
+BFPRT algorithm, which groups the range into fives, sorts each group, and
+selects the median of the groups' medians recursively, in O(n) time.
+
+Together with Partition this is the building block of QuickSelectDeterministic,
+exported for building other deterministic selection variants. The range is
+reordered in the process, but no element outside of it is touched.
+MedianOfMedians panics if the range is empty or isn't within [0, data.Len()).
+*/
+func MedianOfMedians(data Interface, lo, hi int) int {
+	if length := data.Len(); lo < 0 || lo > hi || hi >= length {
+		panic(fmt.Errorf("The specified range [%d,%d] is outside of the data's range of indices [0,%d)", lo, hi, length))
+	}
+	return medianOfMedians(data, lo, hi)
+}
+
+/*
+Returns the index of an element in the range [low, high] that's guaranteed to
+be greater than about 30% of the range and smaller than about 30% of it.
+
+The range is split into groups of five whose medians are moved to the front of
+the range, and the median of those is then found by recursively selecting among
+them.
+*/
+func medianOfMedians(data Interface, low, high int) int {
+	if high-low < 5 {
+		insertionSort(data, low, high+1)
+		return low + (high-low)/2
+	}
+
+	medians := low
+	for i := low; i <= high; i += 5 {
+		end := min(i+4, high)
+		insertionSort(data, i, end+1)
+		data.Swap(medians, i+(end-i)/2)
+		medians++
+	}
+
+	mid := low + (medians-1-low)/2
+	deterministicSelectionFinding(data, low, medians-1, mid)
+	return mid
+}
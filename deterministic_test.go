@@ -0,0 +1,111 @@
+package quickselect
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestQuickSelectDeterministic(t *testing.T) {
+	fixtures := []struct {
+		Array []int
+		K     int
+	}{
+		{[]int{0, 14, 16, 29, 12, 2, 4, 4, 7, 29}, 4},
+		{[]int{9, 3, 2, 18}, 4},
+		{[]int{2, 10, 5, 3, 2, 6, 2, 6, 10, 3, 4, 5}, 5},
+		{make([]int, 1000), 500},
+		{organPipe(1001), 300},
+		{organPipe(10000), 1},
+		{organPipe(10000), 9999},
+	}
+
+	for _, fixture := range fixtures {
+		sorted := append([]int(nil), fixture.Array...)
+		sort.Ints(sorted)
+
+		err := QuickSelectDeterministic(IntSlice(fixture.Array), fixture.K)
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+
+		if !hasSameElements(fixture.Array[:fixture.K], sorted[:fixture.K]) {
+			t.Errorf("Expected smallest K elements to be '%v', but got '%v'", sorted[:fixture.K], fixture.Array[:fixture.K])
+		}
+	}
+
+	if err := QuickSelectDeterministic(IntSlice{1}, 2); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestMedianOfMedians(t *testing.T) {
+	for _, n := range []int{1, 4, 5, 26, 100, 1001} {
+		data := IntSlice(organPipe(n))
+		pivot := data[medianOfMedians(data, 0, n-1)]
+
+		smaller, larger := 0, 0
+		for _, elem := range data {
+			if elem < pivot {
+				smaller++
+			} else if elem > pivot {
+				larger++
+			}
+		}
+
+		if n >= 100 && (smaller < 3*n/10-3 || larger < 3*n/10-3) {
+			t.Errorf("Expected median of medians '%d' to be near the middle of %d elements, but %d are smaller and %d larger", pivot, n, smaller, larger)
+		}
+	}
+}
+
+func TestMedianOfMediansExported(t *testing.T) {
+	for _, n := range []int{1, 5, 26, 1000, 12345} {
+		// Sentinels on either side of the range must be left alone.
+		data := append(append(IntSlice{-1}, organPipe(n)...), n)
+		lo, hi := 1, n
+
+		pivotIndex := MedianOfMedians(data, lo, hi)
+		if pivotIndex < lo || pivotIndex > hi {
+			t.Errorf("Expected the median of medians to lie within [%d,%d], but got %d", lo, hi, pivotIndex)
+			continue
+		}
+		if data[0] != -1 || data[len(data)-1] != n {
+			t.Errorf("Expected elements outside of [%d,%d] to be left untouched", lo, hi)
+		}
+
+		pivot := data[pivotIndex]
+		notLarger, notSmaller := 0, 0
+		for _, elem := range data[lo : hi+1] {
+			if elem <= pivot {
+				notLarger++
+			}
+			if elem >= pivot {
+				notSmaller++
+			}
+		}
+		if notLarger < 3*n/10-3 || notSmaller < 3*n/10-3 {
+			t.Errorf("Expected median of medians '%d' to be between the 30th and 70th percentile of %d elements, but %d are no larger and %d no smaller", pivot, n, notLarger, notSmaller)
+		}
+	}
+
+	for _, fixture := range []struct{ Lo, Hi int }{{-1, 2}, {3, 2}, {0, 10}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Should have panicked on range [%d,%d].", fixture.Lo, fixture.Hi)
+				}
+			}()
+			MedianOfMedians(IntSlice(organPipe(10)), fixture.Lo, fixture.Hi)
+		}()
+	}
+}
+
+// organPipe returns n ints which first ascend and then descend again, a
+// classic worst case for naive pivot choices.
+func organPipe(n int) []int {
+	data := make([]int, n)
+	for i := range data {
+		data[i] = min(i, n-i)
+	}
+	return data
+}
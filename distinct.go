@@ -0,0 +1,83 @@
+package quickselect
+
+import (
+	"cmp"
+	"sort"
+)
+
+/*
+SelectDistinct returns a newly allocated slice holding the k smallest distinct
+values of the data, sorted in ascending order. Duplicates are collapsed, so "the
+10 lowest prices" are 10 different prices even if many items share some of
+them. If the data holds fewer than k distinct values, all of them are returned.
+The data itself is left untouched.
+
+The distinct values are kept in a max-heap of at most k values as the data is
+scanned, along with a set of them to recognize duplicates, so this runs in
+O(n log k) time. NaNs are all considered the same value, smaller than any
+other. An error is raised if k is negative.
+*/
+func SelectDistinct[T cmp.Ordered](data []T, k int) ([]T, error) {
+	if k < 0 {
+		return nil, outOfRange(k, len(data))
+	} else if k == 0 {
+		return nil, nil
+	}
+
+	heap := make([]T, 0, min(k, len(data)))
+	set := distinctSet[T]{values: make(map[T]bool, cap(heap))}
+	i := 0
+	for ; i < len(data) && len(heap) < k; i++ {
+		if !set.contains(data[i]) {
+			set.add(data[i])
+			heap = append(heap, data[i])
+		}
+	}
+	for j := len(heap)/2 - 1; j >= 0; j-- {
+		orderedSiftDown(heap, j, len(heap))
+	}
+
+	for _, elem := range data[i:] {
+		if lessOrdered(elem, heap[0]) && !set.contains(elem) {
+			set.remove(heap[0])
+			set.add(elem)
+			heap[0] = elem
+			orderedSiftDown(heap, 0, k)
+		}
+	}
+
+	sort.Slice(heap, func(i, j int) bool {
+		return lessOrdered(heap[i], heap[j])
+	})
+	return heap, nil
+}
+
+// distinctSet is a set of ordered values in which all NaNs are the same value,
+// which a plain map can't do since NaN != NaN.
+type distinctSet[T cmp.Ordered] struct {
+	values map[T]bool
+	nan    bool
+}
+
+func (s *distinctSet[T]) contains(x T) bool {
+	if isNaNOrdered(x) {
+		return s.nan
+	}
+	return s.values[x]
+}
+
+func (s *distinctSet[T]) add(x T) {
+	if isNaNOrdered(x) {
+		s.nan = true
+	} else {
+		s.values[x] = true
+	}
+}
+
+func (s *distinctSet[T]) remove(x T) {
+	if isNaNOrdered(x) {
+		s.nan = false
+	} else {
+		delete(s.values, x)
+	}
+}
@@ -0,0 +1,67 @@
+package quickselect
+
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+	"testing"
+)
+
+func TestSelectDistinct(t *testing.T) {
+	data := make([]int, 10000)
+	for i := range data {
+		data[i] = rand.IntN(300)
+	}
+	original := append([]int(nil), data...)
+
+	unique := make(map[int]bool)
+	for _, elem := range data {
+		unique[elem] = true
+	}
+	var distinct []int
+	for elem := range unique {
+		distinct = append(distinct, elem)
+	}
+	sort.Ints(distinct)
+
+	for _, k := range []int{0, 1, 10, 150, len(distinct), len(distinct) + 10} {
+		values, err := SelectDistinct(data, k)
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		expected := distinct[:min(k, len(distinct))]
+		if len(values) != len(expected) {
+			t.Errorf("Expected %d distinct values, but got %d", len(expected), len(values))
+			continue
+		}
+		for i := range expected {
+			if values[i] != expected[i] {
+				t.Errorf("Expected smallest distinct values to be '%v', but got '%v'", expected, values)
+				break
+			}
+		}
+	}
+	for i := range data {
+		if data[i] != original[i] {
+			t.Errorf("Expected data to be left untouched")
+			break
+		}
+	}
+
+	if _, err := SelectDistinct(data, -1); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestSelectDistinctNaN(t *testing.T) {
+	nan := math.NaN()
+	prices := []float64{9.99, nan, 4.5, 9.99, nan, 4.5, 12, 1.25, nan}
+
+	values, err := SelectDistinct(prices, 3)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if len(values) != 3 || !math.IsNaN(values[0]) || values[1] != 1.25 || values[2] != 4.5 {
+		t.Errorf("Expected smallest distinct values to be [NaN 1.25 4.5], but got '%v'", values)
+	}
+}
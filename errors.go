@@ -0,0 +1,50 @@
+package quickselect
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrKOutOfRange is matched by the errors returned for a k that's outside of
+// the data's range, so that callers can tell them apart with errors.Is.
+var ErrKOutOfRange = errors.New("The specified index is outside of the data's range of indices")
+
+/*
+A RangeError is returned for a k that's outside of the closed range [Min, Max]
+of values that are valid for the call that returned it. For selections that k
+is the number of elements to select, so the range is [0, Len] of the data, and
+for those returning the k-th smallest element it's the rank, so the range is
+[1, Len]. It matches ErrKOutOfRange.
+*/
+type RangeError struct {
+	K   int
+	Min int
+	Max int
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("The specified index '%d' is outside of the valid range [%d,%d]", e.K, e.Min, e.Max)
+}
+
+// Is reports whether the target is ErrKOutOfRange.
+func (e *RangeError) Is(target error) bool {
+	return target == ErrKOutOfRange
+}
+
+// Returns the error for a number of elements k to select that's outside of
+// the range [0, length].
+func outOfRange(k, length int) error {
+	return &RangeError{K: k, Min: 0, Max: length}
+}
+
+// Returns the error for a negative k for data of unknown length, such as a
+// stream, for which any k >= 0 is valid.
+func negativeK(k int) error {
+	return &RangeError{K: k, Min: 0, Max: math.MaxInt}
+}
+
+// Returns the error for a rank k that's outside of the range [1, length].
+func rankOutOfRange(k, length int) error {
+	return &RangeError{K: k, Min: 1, Max: length}
+}
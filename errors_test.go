@@ -0,0 +1,47 @@
+package quickselect
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestRangeError(t *testing.T) {
+	fixtures := []struct {
+		Name string
+		Err  error
+		K    int
+		Min  int
+		Max  int
+	}{
+		{"QuickSelect", QuickSelect(IntSlice{1, 2, 3}, 4), 4, 0, 3},
+		{"IntQuickSelect", IntQuickSelect([]int{1, 2, 3}, -1), -1, 0, 3},
+		{"QuickSelectDeterministic", QuickSelectDeterministic(IntSlice{1, 2}, 3), 3, 0, 2},
+		{"IntSelectKth", func() error { _, err := IntSelectKth([]int{1, 2}, 0); return err }(), 0, 1, 2},
+		{"KthLargest", func() error { _, err := KthLargest([]int{1, 2}, 3); return err }(), 3, 1, 2},
+		{"MultiSelect", MultiSelect(IntSlice{1, 2}, []int{0}), 0, 1, 2},
+		{"wrapped", fmt.Errorf("Row 3: %w", QuickSelect(IntSlice{}, 1)), 1, 0, 0},
+	}
+
+	for _, fixture := range fixtures {
+		if !errors.Is(fixture.Err, ErrKOutOfRange) {
+			t.Errorf("Expected %s to return an error matching ErrKOutOfRange, but got '%v'", fixture.Name, fixture.Err)
+		}
+		var rangeErr *RangeError
+		if !errors.As(fixture.Err, &rangeErr) {
+			t.Errorf("Expected %s to return a RangeError, but got '%v'", fixture.Name, fixture.Err)
+			continue
+		}
+		if rangeErr.K != fixture.K || rangeErr.Min != fixture.Min || rangeErr.Max != fixture.Max {
+			t.Errorf("Expected %s to return a RangeError for k = %d and range [%d,%d], but got %+v", fixture.Name, fixture.K, fixture.Min, fixture.Max, *rangeErr)
+		}
+	}
+
+	err := QuickSelect(IntSlice{1, 2, 3}, 5)
+	if err.Error() != "The specified index '5' is outside of the valid range [0,3]" {
+		t.Errorf("Expected the error message to name the valid range, but got '%s'", err.Error())
+	}
+	if errors.Is(errors.New("some other error"), ErrKOutOfRange) {
+		t.Errorf("Expected unrelated errors not to match ErrKOutOfRange")
+	}
+}
@@ -0,0 +1,14 @@
+package quickselect_test
+
+import (
+	"fmt"
+
+	"github.com/wangjohn/quickselect"
+)
+
+func Example_quickSelectOrdered() {
+	prices := []float64{9.99, 4.5, 12, 3.25, 7}
+	quickselect.QuickSelectOrdered(prices, 2)
+	fmt.Println(prices[:2])
+	// Output: [4.5 3.25]
+}
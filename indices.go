@@ -0,0 +1,93 @@
+package quickselect
+
+import "sort"
+
+/*
+SelectIndices returns the indices of the k smallest elements of a collection of
+length n, as ordered by less, sorted so that the smallest element comes first.
+The collection is never reordered, which makes this suitable for read-only data
+and for columnar data where swapping is expensive.
+
+It uses the heap strategy on a separate array of k indices and so runs in
+O(n log k) time. For k == 0 it returns nil, and it panics if k is outside of
+the range [0, n].
+*/
+func SelectIndices(n, k int, less func(i, j int) bool) []int {
+	if k < 0 || k > n {
+		panic(outOfRange(k, n))
+	} else if k == 0 {
+		return nil
+	}
+
+	data := lessSwap{n: n, less: less}
+	heap := make([]int, k)
+	for i := 0; i < k; i++ {
+		heap[i] = i
+	}
+	heapInit(data, heap)
+
+	for i := k; i < n; i++ {
+		if less(i, heap[0]) {
+			heap[0] = i
+			heapDown(data, heap, 0, k)
+		}
+	}
+
+	sort.Slice(heap, func(i, j int) bool {
+		return less(heap[i], heap[j])
+	})
+	return heap
+}
+
+/*
+SelectSnapshot returns the indices of the k smallest elements of the data,
+sorted so that the smallest element comes first. Only Len and Less are called,
+never Swap, so the data is left untouched and other goroutines may keep reading
+it while the selection runs. The caller must still make sure nothing writes to
+the data concurrently. SelectSnapshot panics if k is outside of the range
+[0, data.Len()].
+*/
+func SelectSnapshot(data Interface, k int) []int {
+	return SelectIndices(data.Len(), k, data.Less)
+}
+
+/*
+SelectMasked returns the indices of the k smallest elements of the data among
+those at the indices for which valid returns true, sorted so that the smallest
+element comes first. Like SelectSnapshot it never calls Swap, so the data is
+left untouched, and the data isn't compacted either: only the valid indices are
+gathered, in one pass that calls valid once per index, and the selection runs on
+them. For a sparse mask that's a small fraction of the data, and Less is only
+ever called on valid elements.
+
+It runs in expected O(n + m + k log k) time for m valid elements. For k == 0 it
+returns nil, and it panics if k is outside of the range [0, m].
+*/
+func SelectMasked(data Interface, valid func(i int) bool, k int) []int {
+	var indices []int
+	for i, length := 0, data.Len(); i < length; i++ {
+		if valid(i) {
+			indices = append(indices, i)
+		}
+	}
+	if k < 0 || k > len(indices) {
+		panic(outOfRange(k, len(indices)))
+	} else if k == 0 {
+		return nil
+	}
+
+	less := func(i, j int) bool {
+		return data.Less(indices[i], indices[j])
+	}
+	new(selection).quickSelectN(lessSwap{
+		n:    len(indices),
+		less: less,
+		swap: func(i, j int) {
+			indices[i], indices[j] = indices[j], indices[i]
+		},
+	}, k, len(indices))
+
+	selected := indices[:k:k]
+	sort.Slice(selected, less)
+	return selected
+}
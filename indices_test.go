@@ -0,0 +1,139 @@
+package quickselect
+
+import "testing"
+
+func TestSelectIndices(t *testing.T) {
+	fixtures := []struct {
+		Array           []int
+		K               int
+		ExpectedIndices []int
+	}{
+		{[]int{0, 14, 16, 29, 12, 2, 5, 4, 7, 30}, 4, []int{0, 5, 7, 6}},
+		{[]int{9, 3, 2, 18}, 4, []int{2, 1, 0, 3}},
+		{[]int{16, 29, -11, 25, 28, -14, 10, 4, 7, -27}, 1, []int{9}},
+	}
+
+	for _, fixture := range fixtures {
+		original := append([]int(nil), fixture.Array...)
+		indices := SelectIndices(len(fixture.Array), fixture.K, func(i, j int) bool {
+			return fixture.Array[i] < fixture.Array[j]
+		})
+
+		if len(indices) != len(fixture.ExpectedIndices) {
+			t.Errorf("Expected indices '%v', but got '%v'", fixture.ExpectedIndices, indices)
+			continue
+		}
+		for i := range indices {
+			if indices[i] != fixture.ExpectedIndices[i] {
+				t.Errorf("Expected indices '%v', but got '%v'", fixture.ExpectedIndices, indices)
+				break
+			}
+		}
+		for i := range original {
+			if fixture.Array[i] != original[i] {
+				t.Errorf("Expected data to be left untouched as '%v', but got '%v'", original, fixture.Array)
+				break
+			}
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Should have panicked on index outside of array length.")
+		}
+	}()
+	SelectIndices(3, 4, func(i, j int) bool { return i < j })
+}
+
+// readOnly panics on Swap, so that tests can make sure the data is never
+// reordered.
+type readOnly struct {
+	Interface
+}
+
+func (r readOnly) Swap(i, j int) {
+	panic("Swap called on read-only data")
+}
+
+func TestSelectSnapshot(t *testing.T) {
+	data := IntSlice{0, 14, 16, 29, 12, 2, 5, 4, 7, 30}
+	expected := []int{0, 5, 7, 6}
+
+	done := make(chan int)
+	go func() {
+		sum := 0
+		for _, elem := range data {
+			sum += elem
+		}
+		done <- sum
+	}()
+
+	indices := SelectSnapshot(readOnly{data}, 4)
+	<-done
+
+	if len(indices) != len(expected) {
+		t.Fatalf("Expected indices '%v', but got '%v'", expected, indices)
+	}
+	for i := range indices {
+		if indices[i] != expected[i] {
+			t.Errorf("Expected indices '%v', but got '%v'", expected, indices)
+			break
+		}
+	}
+}
+
+func TestSelectMasked(t *testing.T) {
+	data := IntSlice{0, 14, 16, 29, 12, 2, 5, 4, 7, 30}
+	even := func(i int) bool { return i%2 == 0 }
+
+	fixtures := []struct {
+		Valid           func(i int) bool
+		K               int
+		ExpectedIndices []int
+	}{
+		{even, 3, []int{0, 6, 8}},
+		{even, 5, []int{0, 6, 8, 4, 2}},
+		{func(i int) bool { return i != 0 }, 2, []int{5, 7}},
+		{func(i int) bool { return i > 6 }, 1, []int{7}},
+		{func(i int) bool { return true }, 4, []int{0, 5, 7, 6}},
+		{func(i int) bool { return false }, 0, nil},
+	}
+
+	for _, fixture := range fixtures {
+		indices := SelectMasked(readOnly{data}, fixture.Valid, fixture.K)
+		if len(indices) != len(fixture.ExpectedIndices) {
+			t.Errorf("Expected indices '%v', but got '%v'", fixture.ExpectedIndices, indices)
+			continue
+		}
+		for i := range indices {
+			if indices[i] != fixture.ExpectedIndices[i] {
+				t.Errorf("Expected indices '%v', but got '%v'", fixture.ExpectedIndices, indices)
+				break
+			}
+		}
+	}
+
+	// Only one in a hundred elements of a large array is valid.
+	large := make(IntSlice, 100000)
+	for i := range large {
+		large[i] = (i * 7919) % len(large)
+	}
+	sparse := func(i int) bool { return i%100 == 0 }
+	indices := SelectMasked(readOnly{large}, sparse, 50)
+	for i, index := range indices {
+		if !sparse(index) || i > 0 && large[indices[i-1]] > large[index] {
+			t.Errorf("Expected sorted indices of valid elements, but got '%v'", indices)
+			break
+		}
+	}
+	if largest := large[indices[len(indices)-1]]; largest != 4900 {
+		t.Errorf("Expected the 50th smallest valid element to be 4900, but got %d", largest)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Should have panicked on index outside of array length.")
+		}
+	}()
+	SelectMasked(data, even, 6)
+}
@@ -0,0 +1,27 @@
+package quickselect
+
+/*
+SelectLenient works like QuickSelect, but is meant for comparators that may not
+be a strict weak ordering, such as a Less that compares NaNs inconsistently or
+keys that change while the selection runs. It never spins: every partition
+shrinks the range, and after bits.Len(n) unbalanced partitions the range is
+heapsorted, so at most O(n log n) comparisons are made whatever Less returns.
+
+Once the selection is done, the first k elements are checked against the rest
+in one more pass of n comparisons. If any of the rest compares smaller than the
+largest of the first k, the comparator can't have been consistent, and
+inconsistent is set. The data is then only a best effort at the smallest k, but
+it is always a permutation of the original. A consistent comparator always
+yields the same result as QuickSelect with inconsistent unset.
+*/
+func SelectLenient(data Interface, k int) (inconsistent bool, err error) {
+	length := data.Len()
+	if err := new(selection).quickSelectN(data, k, length); err != nil {
+		return false, err
+	}
+
+	if k == 0 || k == length {
+		return false, nil
+	}
+	return !isSelected(data, k, length), nil
+}
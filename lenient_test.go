@@ -0,0 +1,88 @@
+package quickselect
+
+import (
+	"math/rand/v2"
+	"sort"
+	"testing"
+)
+
+// coinFlipLess answers every comparison at random, so it's neither consistent
+// nor transitive.
+type coinFlipLess struct {
+	IntSlice
+	rng *rand.Rand
+}
+
+func (t coinFlipLess) Less(i, j int) bool {
+	return t.rng.IntN(2) == 0
+}
+
+// rockPaperScissors orders 0 < 1 < 2 < 0, which isn't transitive.
+type rockPaperScissors struct {
+	IntSlice
+}
+
+func (t rockPaperScissors) Less(i, j int) bool {
+	return (t.IntSlice[i]+1)%3 == t.IntSlice[j]
+}
+
+func TestSelectLenient(t *testing.T) {
+	data := make(IntSlice, 10000)
+	for i := range data {
+		data[i] = rand.IntN(1000)
+	}
+	sorted := append([]int(nil), data...)
+	sort.Ints(sorted)
+
+	for _, k := range []int{0, 1, 10, 500, 5000, 10000} {
+		selected := append(IntSlice(nil), data...)
+		inconsistent, err := SelectLenient(selected, k)
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if inconsistent {
+			t.Errorf("Expected a consistent comparator not to be flagged for k = %d", k)
+		}
+		if !hasSameElements(selected[:k], sorted[:k]) {
+			t.Errorf("Expected SelectLenient to find the smallest %d elements", k)
+		}
+	}
+
+	if _, err := SelectLenient(data, len(data)+1); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestSelectLenientInconsistent(t *testing.T) {
+	fixtures := []struct {
+		Name    string
+		Data    func(IntSlice) Interface
+		Flagged bool
+	}{
+		{"coin flip", func(data IntSlice) Interface { return coinFlipLess{data, rand.New(rand.NewPCG(1, 2))} }, true},
+		// The final check may well miss a cycle, but the selection must
+		// still finish.
+		{"rock paper scissors", func(data IntSlice) Interface { return rockPaperScissors{data} }, false},
+	}
+
+	for _, fixture := range fixtures {
+		for _, k := range []int{1, 10, 500, 5000} {
+			data := make(IntSlice, 10000)
+			for i := range data {
+				data[i] = i % 3
+			}
+			original := append([]int(nil), data...)
+
+			inconsistent, err := SelectLenient(fixture.Data(data), k)
+			if err != nil {
+				t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+			}
+			if fixture.Flagged && !inconsistent {
+				t.Errorf("Expected the %s comparator to be flagged as inconsistent for k = %d", fixture.Name, k)
+			}
+			if !hasSameElements(data, original) {
+				t.Errorf("Expected the %s selection to leave a permutation of the data for k = %d", fixture.Name, k)
+			}
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package quickselect
+
+import (
+	"cmp"
+	"sort"
+)
+
+/*
+MapSmallestKByValue returns the keys of the k entries of the map with the
+smallest values, ordered by value. Entries with equal values are ordered by
+key, so the result doesn't depend on the map's random iteration order even
+when ties straddle the k-th place. NaN values are treated as smaller than any
+other value.
+
+The entries are copied into a pair of slices which are selected on, so this
+takes O(n + k log k) time and O(n) extra space, and leaves the map untouched.
+MapSmallestKByValue panics if k is outside of the range [0, len(m)].
+*/
+func MapSmallestKByValue[K, V cmp.Ordered](m map[K]V, k int) []K {
+	keys := make([]K, 0, len(m))
+	values := make([]V, 0, len(m))
+	for key, value := range m {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+
+	entries := lessSwap{
+		n: len(keys),
+		less: func(i, j int) bool {
+			return lessOrdered(values[i], values[j]) ||
+				!lessOrdered(values[j], values[i]) && keys[i] < keys[j]
+		},
+		swap: func(i, j int) {
+			keys[i], keys[j] = keys[j], keys[i]
+			values[i], values[j] = values[j], values[i]
+		},
+	}
+	if err := QuickSelect(entries, k); err != nil {
+		panic(err)
+	}
+
+	entries.n = k
+	sort.Sort(entries)
+	return keys[:k:k]
+}
@@ -0,0 +1,54 @@
+package quickselect
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMapSmallestKByValue(t *testing.T) {
+	stock := map[string]int{
+		"pears": 12, "apples": 3, "figs": 3, "kiwis": 40,
+		"plums": 3, "limes": 7, "dates": 12, "cherries": 1,
+	}
+
+	fixtures := []struct {
+		K        int
+		Expected []string
+	}{
+		{0, []string{}},
+		{1, []string{"cherries"}},
+		{2, []string{"cherries", "apples"}},
+		{3, []string{"cherries", "apples", "figs"}},
+		{6, []string{"cherries", "apples", "figs", "plums", "limes", "dates"}},
+		{8, []string{"cherries", "apples", "figs", "plums", "limes", "dates", "pears", "kiwis"}},
+	}
+
+	for _, fixture := range fixtures {
+		// Map iteration order differs from run to run, so the ties must be
+		// broken the same way every time.
+		for run := 0; run < 20; run++ {
+			keys := MapSmallestKByValue(stock, fixture.K)
+			if len(keys) != len(fixture.Expected) {
+				t.Fatalf("Expected keys '%v', but got '%v'", fixture.Expected, keys)
+			}
+			for i := range keys {
+				if keys[i] != fixture.Expected[i] {
+					t.Fatalf("Expected keys '%v', but got '%v'", fixture.Expected, keys)
+				}
+			}
+		}
+	}
+
+	scores := map[int]float64{4: 0.5, 9: math.NaN(), 2: -1, 7: 0.5}
+	keys := MapSmallestKByValue(scores, 3)
+	if len(keys) != 3 || keys[0] != 9 || keys[1] != 2 || keys[2] != 4 {
+		t.Errorf("Expected keys '%v', but got '%v'", []int{9, 2, 4}, keys)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Should have panicked on index outside of array length.")
+		}
+	}()
+	MapSmallestKByValue(stock, len(stock)+1)
+}
@@ -0,0 +1,105 @@
+package quickselect
+
+import "fmt"
+
+// A NaNPolicy determines where NaNs are placed when selecting over float64s.
+type NaNPolicy int
+
+const (
+	// NaNSmallestFirst treats NaNs as smaller than any other value, so they
+	// are the first to be selected. This is what Float64Slice does.
+	NaNSmallestFirst NaNPolicy = iota
+	// NaNLargestLast treats NaNs as larger than any other value, so they are
+	// only selected once every other value has been.
+	NaNLargestLast
+	// NaNError refuses to select over data containing NaNs.
+	NaNError
+)
+
+// Float64SelectOptions configures Float64QuickSelectWithOptions.
+type Float64SelectOptions struct {
+	NaNPolicy NaNPolicy
+}
+
+/*
+Float64QuickSelectWithOptions mutates the data so that the first k elements in
+the float64 slice are the k smallest elements in the slice, with NaNs placed
+according to the options' NaNPolicy.
+*/
+func Float64QuickSelectWithOptions(data []float64, k int, opts Float64SelectOptions) error {
+	switch opts.NaNPolicy {
+	case NaNSmallestFirst:
+		return Float64QuickSelect(data, k)
+	case NaNLargestLast:
+		if k < 0 || k > len(data) {
+			return outOfRange(k, len(data))
+		}
+		numbers := moveNaNsToEnd(data)
+		if k >= numbers {
+			return nil
+		}
+		return orderedQuickSelect(data[:numbers], k)
+	case NaNError:
+		for i, f := range data {
+			if isNaN(f) {
+				return fmt.Errorf("The data contains a NaN at index %d", i)
+			}
+		}
+		return Float64QuickSelect(data, k)
+	default:
+		return fmt.Errorf("Unknown NaN policy %d", opts.NaNPolicy)
+	}
+}
+
+/*
+Float64QuickSelectIgnoreNaN mutates the data so that the first k elements in the
+float64 slice are the k smallest elements in the slice that aren't NaN. All the
+NaNs are moved to the end of the slice and their number is returned. An error is
+raised if there are fewer than k elements that aren't NaN.
+*/
+func Float64QuickSelectIgnoreNaN(data []float64, k int) (nans int, err error) {
+	numbers := moveNaNsToEnd(data)
+	if k < 0 || k > numbers {
+		return len(data) - numbers, outOfRange(k, numbers)
+	}
+	return len(data) - numbers, orderedQuickSelect(data[:numbers], k)
+}
+
+// Moves all the NaNs to the end of the data, and returns how many elements
+// aren't NaN.
+func moveNaNsToEnd(data []float64) int {
+	numbers := 0
+	for i, f := range data {
+		if !isNaN(f) {
+			data[i], data[numbers] = data[numbers], data[i]
+			numbers++
+		}
+	}
+	return numbers
+}
+
+/*
+SelectWithNullValue mutates the data so that the first k elements in the int
+slice are the k smallest elements in the slice, with every element equal to
+null treated as larger than any other value, whatever its numeric value. This
+suits a sentinel such as math.MinInt standing in for missing values, which
+would otherwise be the first to be selected. Like NaNLargestLast does for NaNs,
+the nulls are moved to the end in one pass before selecting over the rest.
+*/
+func SelectWithNullValue(data []int, null int, k int) error {
+	if k < 0 || k > len(data) {
+		return outOfRange(k, len(data))
+	}
+
+	values := 0
+	for i, elem := range data {
+		if elem != null {
+			data[i], data[values] = data[values], data[i]
+			values++
+		}
+	}
+	if k >= values {
+		return nil
+	}
+	return orderedQuickSelect(data[:values], k)
+}
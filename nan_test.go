@@ -0,0 +1,121 @@
+package quickselect
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFloat64QuickSelectWithOptions(t *testing.T) {
+	nan := math.NaN()
+	fixtures := []struct {
+		Policy    NaNPolicy
+		K         int
+		ExpectedK []float64
+	}{
+		{NaNSmallestFirst, 3, []float64{nan, nan, -1.25}},
+		{NaNLargestLast, 3, []float64{-1.25, 0, 3.5}},
+		{NaNLargestLast, 5, []float64{-1.25, 0, 3.5, 7, nan}},
+	}
+
+	for _, fixture := range fixtures {
+		data := []float64{3.5, nan, -1.25, 7, nan, 0}
+		err := Float64QuickSelectWithOptions(data, fixture.K, Float64SelectOptions{fixture.Policy})
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if countNaNs(data[:fixture.K]) != countNaNs(fixture.ExpectedK) ||
+			!hasSameElementsFloat64(withoutNaNs(data[:fixture.K]), withoutNaNs(fixture.ExpectedK)) {
+			t.Errorf("Expected smallest K elements to be '%v', but got '%v'", fixture.ExpectedK, data[:fixture.K])
+		}
+	}
+
+	err := Float64QuickSelectWithOptions([]float64{1, nan}, 1, Float64SelectOptions{NaNError})
+	if err == nil {
+		t.Errorf("Should have raised error on NaN.")
+	}
+	err = Float64QuickSelectWithOptions([]float64{2, 1}, 1, Float64SelectOptions{NaNError})
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	err = Float64QuickSelectWithOptions([]float64{1, nan}, 3, Float64SelectOptions{NaNLargestLast})
+	if err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestFloat64QuickSelectIgnoreNaN(t *testing.T) {
+	nan := math.NaN()
+	data := []float64{3.5, nan, -1.25, 7, nan, 0}
+
+	nans, err := Float64QuickSelectIgnoreNaN(data, 2)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if nans != 2 {
+		t.Errorf("Expected 2 NaNs to be found, but got %d", nans)
+	}
+	if !hasSameElementsFloat64(data[:2], []float64{-1.25, 0}) {
+		t.Errorf("Expected smallest K elements to be '%v', but got '%v'", []float64{-1.25, 0}, data[:2])
+	}
+	if !math.IsNaN(data[4]) || !math.IsNaN(data[5]) {
+		t.Errorf("Expected NaNs to be moved to the end, but got '%v'", data)
+	}
+
+	nans, err = Float64QuickSelectIgnoreNaN(data, 5)
+	if err == nil {
+		t.Errorf("Should have raised error when there are fewer than k numbers.")
+	}
+	if nans != 2 {
+		t.Errorf("Expected 2 NaNs to be found, but got %d", nans)
+	}
+}
+
+func countNaNs(data []float64) int {
+	nans := 0
+	for _, f := range data {
+		if math.IsNaN(f) {
+			nans++
+		}
+	}
+	return nans
+}
+
+func withoutNaNs(data []float64) []float64 {
+	var numbers []float64
+	for _, f := range data {
+		if !math.IsNaN(f) {
+			numbers = append(numbers, f)
+		}
+	}
+	return numbers
+}
+
+func TestSelectWithNullValue(t *testing.T) {
+	null := math.MinInt
+	fixtures := []struct {
+		Array     []int
+		K         int
+		ExpectedK []int
+	}{
+		{[]int{16, null, -11, 25, null, -14, 10}, 3, []int{-14, -11, 10}},
+		{[]int{16, null, -11, 25, null, -14, 10}, 5, []int{-14, -11, 10, 16, 25}},
+		{[]int{16, null, -11, 25, null, -14, 10}, 6, []int{-14, -11, 10, 16, 25, null}},
+		{[]int{null, null}, 1, []int{null}},
+		{[]int{3, 1, 2}, 2, []int{1, 2}},
+		{[]int{}, 0, []int{}},
+	}
+
+	for _, fixture := range fixtures {
+		err := SelectWithNullValue(fixture.Array, null, fixture.K)
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if !hasSameElements(fixture.Array[:fixture.K], fixture.ExpectedK) {
+			t.Errorf("Expected smallest K elements to be '%v', but got '%v'", fixture.ExpectedK, fixture.Array[:fixture.K])
+		}
+	}
+
+	if err := SelectWithNullValue([]int{1, null}, null, 3); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
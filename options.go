@@ -0,0 +1,89 @@
+package quickselect
+
+// Fallback is the algorithm the randomized selection switches to once it has
+// made too many unbalanced partitions.
+type Fallback int
+
+const (
+	// HeapsortFallback sorts what's left of the range with heapsort, which
+	// runs in O(n log n) time with a small constant factor.
+	HeapsortFallback Fallback = iota
+	// MedianOfMediansFallback keeps selecting in what's left of the range,
+	// but picks pivots with the median of medians like
+	// QuickSelectDeterministic, which runs in O(n) time with a large constant
+	// factor.
+	MedianOfMediansFallback
+)
+
+// SwapCost hints at how expensive the data's Swap is compared to its Less, so
+// that the selection strategy can be picked accordingly.
+type SwapCost int
+
+const (
+	// UnknownSwapCost leaves the choice of strategy to QuickSelect, which
+	// picks one by the length of the data and k alone.
+	UnknownSwapCost SwapCost = iota
+	// CheapSwap always picks the randomized strategy, which makes the fewest
+	// comparisons, O(n), at the price of O(n) swaps.
+	CheapSwap
+	// ExpensiveSwap always picks the heap strategy, which makes at most k
+	// swaps, at the price of O(n log k) comparisons. This suits data whose
+	// elements are large structs.
+	ExpensiveSwap
+)
+
+/*
+Options tunes how QuickSelectWithOptions selects. The zero value selects just
+like QuickSelect does.
+*/
+type Options struct {
+	// Limit is the number of unbalanced partitions, which keep more than 7/8
+	// of the range they split, tolerated before switching to the Fallback.
+	// Zero means bits.Len(n) for data of length n, and a negative limit
+	// switches to the Fallback right away.
+	Limit int
+	// Fallback is the algorithm used once the Limit is exhausted.
+	Fallback Fallback
+	// InsertionThreshold is the size of a range at or below which the
+	// randomized selection stops partitioning and insertion sorts the range.
+	// Expensive comparisons favour a larger threshold, cheap ones a smaller
+	// one. Zero means PartitionThreshold, and a negative threshold never
+	// insertion sorts.
+	InsertionThreshold int
+	// TieBreakByIndex breaks ties among the elements equal to the k-th
+	// smallest by their original index, so that the ones coming first in the
+	// input are chosen for the block whatever pivots are picked. Selection
+	// then works like StableSelect and needs O(n) extra space.
+	TieBreakByIndex bool
+	// SwapCostHint overrides the choice between the randomized and the heap
+	// strategy by which of Swap and Less dominates. It's ignored if
+	// TieBreakByIndex is set.
+	SwapCostHint SwapCost
+}
+
+/*
+QuickSelectWithOptions works like QuickSelect, but lets the caller trade the
+speed of randomized selection against how soon it gives up on unlucky pivots.
+A higher limit tolerates more quickselect rounds before paying for the
+fallback, while a lower one gets to the fallback's guaranteed bound sooner.
+Ties can also be broken by index, which makes the outcome reproducible from run
+to run.
+*/
+func QuickSelectWithOptions(data Interface, k int, opts Options) error {
+	s := selection{
+		limit:     opts.Limit,
+		fallback:  opts.Fallback,
+		insertion: opts.InsertionThreshold,
+		swapCost:  opts.SwapCostHint,
+	}
+	if opts.TieBreakByIndex {
+		return s.stableSelect(data, k)
+	}
+	return s.quickSelect(data, k)
+}
+
+// QuickSelectWithLimit works like QuickSelect, but tolerates limit unbalanced
+// partitions before falling back to heapsort. See Options for details.
+func QuickSelectWithLimit(data Interface, k, limit int) error {
+	return QuickSelectWithOptions(data, k, Options{Limit: limit})
+}
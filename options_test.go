@@ -0,0 +1,155 @@
+package quickselect
+
+import (
+	"math/rand/v2"
+	"sort"
+	"testing"
+)
+
+// lessCounter counts the comparisons made on the data it wraps.
+type lessCounter struct {
+	Interface
+	compares int
+}
+
+func (c *lessCounter) Less(i, j int) bool {
+	c.compares++
+	return c.Interface.Less(i, j)
+}
+
+func TestQuickSelectWithOptions(t *testing.T) {
+	fallbacks := []Fallback{HeapsortFallback, MedianOfMediansFallback}
+	limits := []int{-1, 0, 1, 64}
+
+	for _, fallback := range fallbacks {
+		for _, limit := range limits {
+			for _, k := range []int{2, 500, 4999} {
+				array := make([]int, 5000)
+				for i := range array {
+					array[i] = rand.IntN(100)
+				}
+				expected := append([]int(nil), array...)
+				sort.Ints(expected)
+
+				err := QuickSelectWithOptions(IntSlice(array), k, Options{Limit: limit, Fallback: fallback})
+				if err != nil {
+					t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+				}
+				if !hasSameElements(array[:k], expected[:k]) {
+					t.Errorf("Wrong smallest %d elements with limit %d and fallback %d", k, limit, fallback)
+				}
+			}
+		}
+	}
+
+	if err := QuickSelectWithLimit(IntSlice{1, 2, 3}, 4, 1); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestQuickSelectWithLimitDuplicates(t *testing.T) {
+	// Every partition of equal elements is as unbalanced as it gets, so only
+	// the fallback keeps this from taking quadratic time.
+	size := 100000
+	for _, fallback := range []Fallback{HeapsortFallback, MedianOfMediansFallback} {
+		data := &lessCounter{Interface: make(IntSlice, size)}
+		if err := QuickSelectWithOptions(data, size/2, Options{Fallback: fallback}); err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if data.compares > 100*size {
+			t.Errorf("Expected at most %d comparisons, but got %d", 100*size, data.compares)
+		}
+	}
+}
+
+func TestHeapSort(t *testing.T) {
+	array := []int{50, 20, 30, 25, 45, 2, 6, 10, 3, 4, 5}
+	heapSort(IntSlice(array), 2, 9)
+	expected := []int{50, 20, 2, 3, 6, 10, 25, 30, 45, 4, 5}
+	for i := range array {
+		if array[i] != expected[i] {
+			t.Errorf("Expected '%v', but got '%v'", expected, array)
+			break
+		}
+	}
+}
+
+func TestQuickSelectWithOptionsSwapCostHint(t *testing.T) {
+	size := 10000
+	array := make([]int, size)
+	for i := range array {
+		array[i] = rand.IntN(size)
+	}
+	expected := append([]int(nil), array...)
+	sort.Ints(expected)
+
+	for _, k := range []int{2, 50, 5000, 9999} {
+		cheap := &swapCounter{Interface: append(IntSlice(nil), array...)}
+		if err := QuickSelectWithOptions(cheap, k, Options{SwapCostHint: CheapSwap}); err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		expensive := &swapCounter{Interface: append(IntSlice(nil), array...)}
+		if err := QuickSelectWithOptions(expensive, k, Options{SwapCostHint: ExpensiveSwap}); err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+
+		if !hasSameElements(cheap.Interface.(IntSlice)[:k], expected[:k]) || !hasSameElements(expensive.Interface.(IntSlice)[:k], expected[:k]) {
+			t.Errorf("Wrong smallest %d elements with a swap cost hint", k)
+		}
+		if expensive.swaps > k {
+			t.Errorf("Expected at most %d swaps with ExpensiveSwap, but got %d", k, expensive.swaps)
+		}
+		// Partitioning moves around far more than a small k elements, while
+		// for a large k a lucky pivot may need fewer than k swaps in all.
+		if k <= 50 && cheap.swaps <= k {
+			t.Errorf("Expected the randomized strategy to make more than %d swaps with CheapSwap, but got %d", k, cheap.swaps)
+		}
+	}
+}
+
+func TestQuickSelectWithOptionsTieBreakByIndex(t *testing.T) {
+	// Among the records with key 2, the two that come first in the input are
+	// chosen every time, whichever pivots are picked.
+	input := records{{2, 0}, {3, 1}, {2, 2}, {1, 3}, {2, 4}, {0, 5}, {2, 6}, {3, 7}, {2, 8}, {1, 9}}
+	expected := records{{2, 0}, {2, 2}, {1, 3}, {0, 5}, {1, 9}}
+
+	for run := 0; run < 100; run++ {
+		data := append(records(nil), input...)
+
+		if err := QuickSelectWithOptions(data, 5, Options{TieBreakByIndex: true, Limit: -1 + run%2}); err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		for i := range expected {
+			if data[i] != expected[i] {
+				t.Fatalf("Expected '%v', but got '%v'", expected, data[:5])
+			}
+		}
+	}
+}
+
+func TestQuickSelectWithOptionsInsertionThreshold(t *testing.T) {
+	for _, threshold := range []int{-1, 0, 1, 12, 100, 10000} {
+		array := make([]int, 5000)
+		for i := range array {
+			array[i] = rand.IntN(1000)
+		}
+		expected := append([]int(nil), array...)
+		sort.Ints(expected)
+
+		if err := QuickSelectWithOptions(IntSlice(array), 1234, Options{InsertionThreshold: threshold}); err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if !hasSameElements(array[:1234], expected[:1234]) {
+			t.Errorf("Wrong smallest 1234 elements with an insertion threshold of %d", threshold)
+		}
+	}
+
+	// With a threshold covering the whole data, it's insertion sorted.
+	data := IntSlice{50, 20, 30, 25, 45, 2, 6, 10, 3, 4, 5, 1, 9, 8, 7, 12, 11, 0}
+	if err := QuickSelectWithOptions(data, 11, Options{InsertionThreshold: len(data)}); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !sort.IsSorted(data) {
+		t.Errorf("Expected data to be insertion sorted, but got '%v'", data)
+	}
+}
@@ -0,0 +1,507 @@
+package quickselect
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"sort"
+)
+
+// lessOrdered reports whether a sorts before b. It is cmp.Less, so for floating
+// point types a NaN is considered smaller than any other value, which matches
+// Float64Slice.
+func lessOrdered[T cmp.Ordered](a, b T) bool {
+	return cmp.Less(a, b)
+}
+
+// isNaNOrdered reports whether x is a floating point NaN. It is always false for
+// integers and strings.
+func isNaNOrdered[T cmp.Ordered](x T) bool {
+	return x != x
+}
+
+// QuickSelectOrdered mutates the data so that the first k elements in the
+// slice are the k smallest elements in the slice. It works on slices of any
+// ordered type, so there's no need to wrap them in IntSlice, Float64Slice or
+// StringSlice first. NaNs are treated as smaller than any other value.
+func QuickSelectOrdered[T cmp.Ordered](data []T, k int) error {
+	return orderedQuickSelect(data, k)
+}
+
+/*
+Select2 mutates the data so that the first k elements in the slice are the k
+smallest elements in the slice, ordered by cmp.Less from the standard library.
+That is a total order for every ordered type: a NaN is smaller than any other
+value and equal to any other NaN, and -0.0 equals 0.0.
+
+This is exactly the order Float64Slice.Less implements by hand, so for float64
+data Select2 and Float64QuickSelect select the same elements, NaNs included.
+The difference is that Select2 gets its NaN semantics from the standard
+library rather than from this package, and covers float32 and every other
+ordered type with the same guarantee.
+*/
+func Select2[T cmp.Ordered](data []T, k int) error {
+	return orderedSelect(data, k, false)
+}
+
+// QuickSelectLargestOrdered mutates the data so that the first k elements in
+// the slice are the k largest elements in the slice, in no particular order.
+// Rather than going through Reverse, it inverts the comparison on the slice's
+// own type, so it's just as fast as QuickSelectOrdered. NaNs are treated as
+// smaller than any other value, so they're the last to be selected.
+func QuickSelectLargestOrdered[T cmp.Ordered](data []T, k int) error {
+	return orderedSelect(data, k, true)
+}
+
+// KthLargest returns the k-th largest element of the data, so k == 1 yields the
+// maximum and k == len(data) the minimum. Like QuickSelectLargestOrdered it
+// leaves the k largest elements in the first k positions, and additionally
+// places the returned element at index k-1. NaNs are treated as smaller than
+// any other value.
+func KthLargest[T cmp.Ordered](data []T, k int) (T, error) {
+	if k < 1 || k > len(data) {
+		var zero T
+		return zero, rankOutOfRange(k, len(data))
+	}
+	orderedSelect(data, k, true)
+
+	smallest := 0
+	for i := 1; i < k; i++ {
+		if lessOrdered(data[i], data[smallest]) {
+			smallest = i
+		}
+	}
+	data[smallest], data[k-1] = data[k-1], data[smallest]
+	return data[k-1], nil
+}
+
+/*
+QuickSelectBy mutates the data so that the first k elements in the slice are
+the k elements with the smallest keys, as extracted by the key function:
+
+	products := []Product{...}
+	quickselect.QuickSelectBy(products, 10, func(p Product) float64 { return p.Price })
+
+Keys are extracted once per element up front and kept in a parallel slice, so
+key is called exactly len(data) times no matter how many comparisons the
+selection makes. NaN keys are treated as smaller than any other key.
+*/
+func QuickSelectBy[T any, K cmp.Ordered](data []T, k int, key func(T) K) error {
+	length := len(data)
+	if k < 0 || k > length {
+		return outOfRange(k, length)
+	} else if k == 0 {
+		return nil
+	}
+
+	keys := make([]K, length)
+	for i := range data {
+		keys[i] = key(data[i])
+	}
+	return QuickSelect(lessSwap{
+		n: length,
+		less: func(i, j int) bool {
+			return lessOrdered(keys[i], keys[j])
+		},
+		swap: func(i, j int) {
+			keys[i], keys[j] = keys[j], keys[i]
+			data[i], data[j] = data[j], data[i]
+		},
+	}, k)
+}
+
+/*
+The functions below mirror the selection strategies used by QuickSelect, but
+work on slices of ordered types directly. Comparing and swapping elements of a
+concrete slice can be inlined, whereas going through Interface costs a dynamic
+method call for every single comparison and swap.
+*/
+
+// Selects the smallest k elements of the data.
+func orderedQuickSelect[T cmp.Ordered](data []T, k int) error {
+	return orderedSelect(data, k, false)
+}
+
+// Picks and runs the selection strategy best suited for the data and k. The
+// largest k elements are selected instead if desc is set, by the mirrors of
+// the functions below with their comparisons inverted. Branching on desc once
+// per call rather than once per comparison keeps the ascending path as fast as
+// it's always been.
+func orderedSelect[T cmp.Ordered](data []T, k int, desc bool) error {
+	length := len(data)
+	if k < 0 || k > length {
+		return outOfRange(k, length)
+	} else if k == 0 || k == length {
+		return nil
+	} else if desc {
+		orderedSelectLargest(data, k)
+		return nil
+	} else if k == 1 {
+		if minimum := orderedFindMinimum(data); minimum != 0 {
+			data[0], data[minimum] = data[minimum], data[0]
+		}
+		return nil
+	} else if orderedIsSelected(data, k) {
+		return nil
+	}
+
+	if chooseStrategy(length, k) == randomizedStrategy {
+		orderedRandomizedSelectionFinding(data, 0, length-1, k)
+	} else {
+		orderedHeapSelectionFinding(data, k)
+	}
+	return nil
+}
+
+// Mirrors findMinimum.
+func orderedFindMinimum[T cmp.Ordered](data []T) int {
+	minimum := 0
+	for i := 1; i < len(data); i++ {
+		if lessOrdered(data[i], data[minimum]) {
+			minimum = i
+		}
+	}
+	return minimum
+}
+
+// Mirrors isSelected.
+func orderedIsSelected[T cmp.Ordered](data []T, k int) bool {
+	largest := data[0]
+	for _, elem := range data[1:k] {
+		if lessOrdered(largest, elem) {
+			largest = elem
+		}
+	}
+	for _, elem := range data[k:] {
+		if lessOrdered(elem, largest) {
+			return false
+		}
+	}
+	return true
+}
+
+// Mirrors randomizedSelectionFinding.
+func orderedRandomizedSelectionFinding[T cmp.Ordered](data []T, low, high, k int) {
+	orderedNarrowDown(data, low, high, k, nil)
+}
+
+/*
+A blockAccumulator is told about the elements that orderedNarrowDown settles
+into the block of the smallest k, as it settles them, so that it can gather
+something about the block without a pass of its own. It does the partitioning
+itself, so that it can look at the elements while they're being moved.
+*/
+type blockAccumulator[T cmp.Ordered] interface {
+	// partition partitions the range [low, high] around the element at
+	// pivotIndex, like orderedPartitionEqual if equal is set and like
+	// orderedPartition otherwise, and returns where the pivot ended up.
+	partition(data []T, low, high, pivotIndex int, equal bool) int
+	// settle is told that the elements in [low, end) belong to the block. If
+	// partitioned is set, those are the elements left of or at the pivot of
+	// the last call to partition, otherwise they have to be looked at anew.
+	settle(data []T, low, end int, partitioned bool)
+}
+
+// Narrows the range [low, high] down around k like randomizedSelectionFinding
+// does, telling the accumulator, if any, about every element that is settled
+// into the block of the smallest k along the way.
+func orderedNarrowDown[T cmp.Ordered](data []T, low, high, k int, block blockAccumulator[T]) {
+	var pivotIndex int
+
+	for {
+		if low >= high {
+			break
+		} else if high-low <= PartitionThreshold {
+			orderedInsertionSort(data, low, high+1)
+			break
+		}
+
+		pivotIndex = rand.IntN(high+1-low) + low
+		equal := low > 0 && !lessOrdered(data[low-1], data[pivotIndex])
+		if block != nil {
+			pivotIndex = block.partition(data, low, high, pivotIndex, equal)
+		} else if equal {
+			pivotIndex = orderedPartitionEqual(data, low, high, pivotIndex)
+		} else {
+			pivotIndex = orderedPartition(data, low, high, pivotIndex)
+		}
+
+		if k == pivotIndex || equal && k < pivotIndex {
+			if block != nil {
+				block.settle(data, low, k, true)
+			}
+			return
+		} else if k < pivotIndex {
+			high = pivotIndex - 1
+		} else {
+			if block != nil {
+				block.settle(data, low, pivotIndex+1, true)
+			}
+			low = pivotIndex + 1
+		}
+	}
+
+	if block != nil && low < k {
+		block.settle(data, low, k, false)
+	}
+}
+
+// Mirrors insertionSort.
+func orderedInsertionSort[T cmp.Ordered](data []T, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && lessOrdered(data[j], data[j-1]); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// Mirrors partition.
+func orderedPartition[T cmp.Ordered](data []T, low, high, pivotIndex int) int {
+	partitionIndex := low
+	data[pivotIndex], data[high] = data[high], data[pivotIndex]
+	pivot := data[high]
+	for i := low; i < high; i++ {
+		if lessOrdered(data[i], pivot) {
+			data[i], data[partitionIndex] = data[partitionIndex], data[i]
+			partitionIndex++
+		}
+	}
+	data[partitionIndex], data[high] = data[high], data[partitionIndex]
+	return partitionIndex
+}
+
+// Mirrors partitionEqual.
+func orderedPartitionEqual[T cmp.Ordered](data []T, low, high, pivotIndex int) int {
+	partitionIndex := low
+	data[pivotIndex], data[low] = data[low], data[pivotIndex]
+	pivot := data[low]
+	for i := low + 1; i <= high; i++ {
+		if !lessOrdered(pivot, data[i]) {
+			partitionIndex++
+			data[i], data[partitionIndex] = data[partitionIndex], data[i]
+		}
+	}
+	return partitionIndex
+}
+
+// Mirrors heapDown.
+func orderedHeapDown[T cmp.Ordered](data []T, heap []int, i, n int) {
+	for {
+		if i >= n/2 || i < 0 { // i has no children, so 2*i+1 can't overflow
+			break
+		}
+		j1 := 2*i + 1
+		j := j1 // left child
+		if j2 := j1 + 1; j2 < n && lessOrdered(data[heap[j1]], data[heap[j2]]) {
+			j = j2 // right child
+		}
+		if !lessOrdered(data[heap[i]], data[heap[j]]) {
+			break
+		}
+		heap[i], heap[j] = heap[j], heap[i]
+		i = j
+	}
+}
+
+// Mirrors siftDown, on a heap of the values themselves.
+func orderedSiftDown[T cmp.Ordered](data []T, root, n int) {
+	for {
+		child := 2*root + 1
+		if child >= n {
+			return
+		}
+		if child+1 < n && lessOrdered(data[child], data[child+1]) {
+			child++
+		}
+		if !lessOrdered(data[root], data[child]) {
+			return
+		}
+		data[root], data[child] = data[child], data[root]
+		root = child
+	}
+}
+
+// Mirrors heapSelectionFinding, which also covers naiveSelectionFinding since
+// both keep the smallest k indices seen so far.
+func orderedHeapSelectionFinding[T cmp.Ordered](data []T, k int) {
+	heap := make([]int, k)
+	for i := 0; i < k; i++ {
+		heap[i] = i
+	}
+	for i := k/2 - 1; i >= 0; i-- {
+		orderedHeapDown(data, heap, i, k)
+	}
+
+	for i := k; i < len(data); i++ {
+		if lessOrdered(data[i], data[heap[0]]) {
+			heap[0] = i
+			orderedHeapDown(data, heap, 0, k)
+		}
+	}
+
+	sort.Ints(heap)
+	for i := 0; i < k; i++ {
+		data[i], data[heap[i]] = data[heap[i]], data[i]
+	}
+}
+
+/*
+The functions below mirror the ones above with every comparison inverted, so
+that they select the largest k elements. Keeping them apart, rather than
+branching on the direction in every comparison, keeps the ascending path free
+of the branch, which slowed the IntQuickSelect benchmarks down by 15% to 40%.
+*/
+
+// Mirrors orderedSelect for the largest k elements, for 1 <= k < len(data).
+func orderedSelectLargest[T cmp.Ordered](data []T, k int) {
+	if k == 1 {
+		if maximum := orderedFindMaximum(data); maximum != 0 {
+			data[0], data[maximum] = data[maximum], data[0]
+		}
+		return
+	} else if orderedIsSelectedLargest(data, k) {
+		return
+	}
+
+	if chooseStrategy(len(data), k) == randomizedStrategy {
+		orderedRandomizedSelectionFindingLargest(data, 0, len(data)-1, k)
+	} else {
+		orderedHeapSelectionFindingLargest(data, k)
+	}
+}
+
+// Mirrors orderedFindMinimum.
+func orderedFindMaximum[T cmp.Ordered](data []T) int {
+	maximum := 0
+	for i := 1; i < len(data); i++ {
+		if lessOrdered(data[maximum], data[i]) {
+			maximum = i
+		}
+	}
+	return maximum
+}
+
+// Mirrors orderedIsSelected.
+func orderedIsSelectedLargest[T cmp.Ordered](data []T, k int) bool {
+	smallest := data[0]
+	for _, elem := range data[1:k] {
+		if lessOrdered(elem, smallest) {
+			smallest = elem
+		}
+	}
+	for _, elem := range data[k:] {
+		if lessOrdered(smallest, elem) {
+			return false
+		}
+	}
+	return true
+}
+
+// Mirrors orderedRandomizedSelectionFinding.
+func orderedRandomizedSelectionFindingLargest[T cmp.Ordered](data []T, low, high, k int) {
+	var pivotIndex int
+
+	for {
+		if low >= high {
+			return
+		} else if high-low <= PartitionThreshold {
+			orderedInsertionSortLargest(data, low, high+1)
+			return
+		}
+
+		pivotIndex = rand.IntN(high+1-low) + low
+		equal := low > 0 && !lessOrdered(data[pivotIndex], data[low-1])
+		if equal {
+			pivotIndex = orderedPartitionEqualLargest(data, low, high, pivotIndex)
+		} else {
+			pivotIndex = orderedPartitionLargest(data, low, high, pivotIndex)
+		}
+
+		if k == pivotIndex || equal && k < pivotIndex {
+			return
+		} else if k < pivotIndex {
+			high = pivotIndex - 1
+		} else {
+			low = pivotIndex + 1
+		}
+	}
+}
+
+// Mirrors orderedInsertionSort.
+func orderedInsertionSortLargest[T cmp.Ordered](data []T, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && lessOrdered(data[j-1], data[j]); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// Mirrors orderedPartition.
+func orderedPartitionLargest[T cmp.Ordered](data []T, low, high, pivotIndex int) int {
+	partitionIndex := low
+	data[pivotIndex], data[high] = data[high], data[pivotIndex]
+	pivot := data[high]
+	for i := low; i < high; i++ {
+		if lessOrdered(pivot, data[i]) {
+			data[i], data[partitionIndex] = data[partitionIndex], data[i]
+			partitionIndex++
+		}
+	}
+	data[partitionIndex], data[high] = data[high], data[partitionIndex]
+	return partitionIndex
+}
+
+// Mirrors orderedPartitionEqual.
+func orderedPartitionEqualLargest[T cmp.Ordered](data []T, low, high, pivotIndex int) int {
+	partitionIndex := low
+	data[pivotIndex], data[low] = data[low], data[pivotIndex]
+	pivot := data[low]
+	for i := low + 1; i <= high; i++ {
+		if !lessOrdered(data[i], pivot) {
+			partitionIndex++
+			data[i], data[partitionIndex] = data[partitionIndex], data[i]
+		}
+	}
+	return partitionIndex
+}
+
+// Mirrors orderedHeapDown.
+func orderedHeapDownLargest[T cmp.Ordered](data []T, heap []int, i, n int) {
+	for {
+		if i >= n/2 || i < 0 { // i has no children, so 2*i+1 can't overflow
+			break
+		}
+		j1 := 2*i + 1
+		j := j1 // left child
+		if j2 := j1 + 1; j2 < n && lessOrdered(data[heap[j2]], data[heap[j1]]) {
+			j = j2 // right child
+		}
+		if !lessOrdered(data[heap[j]], data[heap[i]]) {
+			break
+		}
+		heap[i], heap[j] = heap[j], heap[i]
+		i = j
+	}
+}
+
+// Mirrors orderedHeapSelectionFinding.
+func orderedHeapSelectionFindingLargest[T cmp.Ordered](data []T, k int) {
+	heap := make([]int, k)
+	for i := 0; i < k; i++ {
+		heap[i] = i
+	}
+	for i := k/2 - 1; i >= 0; i-- {
+		orderedHeapDownLargest(data, heap, i, k)
+	}
+
+	for i := k; i < len(data); i++ {
+		if lessOrdered(data[heap[0]], data[i]) {
+			heap[0] = i
+			orderedHeapDownLargest(data, heap, 0, k)
+		}
+	}
+
+	sort.Ints(heap)
+	for i := 0; i < k; i++ {
+		data[i], data[heap[i]] = data[heap[i]], data[i]
+	}
+}
@@ -0,0 +1,302 @@
+package quickselect
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestQuickSelectOrdered(t *testing.T) {
+	ints := []int{16, 29, -11, 25, 28, -14, 10, 4, 7, -27}
+	err := QuickSelectOrdered(ints, 4)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElements(ints[:4], []int{-27, -11, -14, 4}) {
+		t.Errorf("Expected smallest K elements to be '%v', but got '%v'", []int{-27, -11, -14, 4}, ints[:4])
+	}
+
+	uints := []uint64{9, 3, 2, 18, 1 << 63, 0}
+	err = QuickSelectOrdered(uints, 3)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElementsOrdered(uints[:3], []uint64{0, 2, 3}) {
+		t.Errorf("Expected smallest K elements to be '%v', but got '%v'", []uint64{0, 2, 3}, uints[:3])
+	}
+
+	strings := []string{"pear", "apple", "fig", "banana", "cherry"}
+	err = QuickSelectOrdered(strings, 2)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElementsOrdered(strings[:2], []string{"apple", "banana"}) {
+		t.Errorf("Expected smallest K elements to be '%v', but got '%v'", []string{"apple", "banana"}, strings[:2])
+	}
+
+	err = QuickSelectOrdered([]int{1, 2, 3}, 4)
+	if err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestQuickSelectOrderedNaN(t *testing.T) {
+	floats := []float64{3.5, math.NaN(), -1.25, 7, math.NaN(), 0}
+	err := QuickSelectOrdered(floats, 3)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+
+	nans := 0
+	for _, f := range floats[:3] {
+		if math.IsNaN(f) {
+			nans++
+		}
+	}
+	if nans != 2 {
+		t.Errorf("Expected NaNs to be the smallest elements, but got '%v'", floats[:3])
+	}
+}
+
+func TestSelect2(t *testing.T) {
+	nan := math.NaN()
+	floats := []float64{0, math.Inf(1), nan, -1, math.Copysign(0, -1), nan, math.Inf(-1), 2}
+	for i := range floats {
+		for j := range floats {
+			a, b := floats[i], floats[j]
+			if lessOrdered(a, b) != cmp.Less(a, b) || Float64Slice(floats).Less(i, j) != cmp.Less(a, b) {
+				t.Errorf("Expected Less(%g, %g) to agree with cmp.Less", a, b)
+			}
+		}
+	}
+
+	for k := 0; k <= len(floats); k++ {
+		selected := append([]float64(nil), floats...)
+		if err := Select2(selected, k); err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		expected := append([]float64(nil), floats...)
+		Float64QuickSelect(expected, k)
+		sort.Float64s(selected[:k])
+		sort.Float64s(expected[:k])
+		if !slices.EqualFunc(selected[:k], expected[:k], func(a, b float64) bool { return cmp.Compare(a, b) == 0 }) {
+			t.Errorf("Expected Select2 to select '%v' like Float64QuickSelect, but got '%v'", expected[:k], selected[:k])
+		}
+	}
+
+	float32s := []float32{3, float32(nan), -2, 1}
+	if err := Select2(float32s, 2); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !math.IsNaN(float64(float32s[0])) && !math.IsNaN(float64(float32s[1])) {
+		t.Errorf("Expected NaN to be among the 2 smallest elements, but got '%v'", float32s[:2])
+	}
+
+	if err := Select2([]string{"a"}, 2); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func hasSameElementsOrdered[T comparable](array1, array2 []T) bool {
+	elements := make(map[T]int)
+
+	for _, elem1 := range array1 {
+		elements[elem1]++
+	}
+
+	for _, elem2 := range array2 {
+		elements[elem2]--
+	}
+
+	for _, count := range elements {
+		if count != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestOrderedSelectionStrategies(t *testing.T) {
+	data := randomInts(100000, 20000, 11)
+	sorted := append([]int(nil), data...)
+	sort.Ints(sorted)
+	reversed := append([]int(nil), data...)
+	sort.Sort(sort.Reverse(sort.IntSlice(reversed)))
+
+	for _, k := range []int{1, 5, 50, 100, 1000, 50000, 99999, 100000} {
+		if k <= HeapSelectionThreshold {
+			heap := append([]int(nil), data...)
+			orderedHeapSelectionFinding(heap, k)
+			if !hasSameElements(heap[:k], sorted[:k]) {
+				t.Errorf("Expected heap selection to find the smallest %d elements", k)
+			}
+
+			heap = append(heap[:0], data...)
+			orderedHeapSelectionFindingLargest(heap, k)
+			if !hasSameElements(heap[:k], reversed[:k]) {
+				t.Errorf("Expected heap selection to find the largest %d elements", k)
+			}
+		}
+
+		randomized := append([]int(nil), data...)
+		orderedRandomizedSelectionFinding(randomized, 0, len(randomized)-1, k)
+		if !hasSameElements(randomized[:k], sorted[:k]) {
+			t.Errorf("Expected randomized selection to find the smallest %d elements", k)
+		}
+
+		randomized = append(randomized[:0], data...)
+		orderedRandomizedSelectionFindingLargest(randomized, 0, len(randomized)-1, k)
+		if !hasSameElements(randomized[:k], reversed[:k]) {
+			t.Errorf("Expected randomized selection to find the largest %d elements", k)
+		}
+
+		dispatched := append([]int(nil), data...)
+		if err := IntQuickSelect(dispatched, k); err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if !hasSameElements(dispatched[:k], sorted[:k]) {
+			t.Errorf("Expected IntQuickSelect to find the smallest %d elements", k)
+		}
+	}
+}
+
+func TestQuickSelectBy(t *testing.T) {
+	type product struct {
+		name  string
+		price float64
+	}
+	products := []product{
+		{"lamp", 24.99}, {"mug", 7.5}, {"desk", 199}, {"pen", 1.25},
+		{"chair", 89}, {"stapler", 12}, {"notebook", 3.4}, {"monitor", 149},
+	}
+
+	calls := 0
+	err := QuickSelectBy(products, 3, func(p product) float64 {
+		calls++
+		return p.price
+	})
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if calls != len(products) {
+		t.Errorf("Expected key to be called %d times, but got %d", len(products), calls)
+	}
+
+	names := make(map[string]bool)
+	for _, p := range products[:3] {
+		names[p.name] = true
+	}
+	for _, name := range []string{"pen", "notebook", "mug"} {
+		if !names[name] {
+			t.Errorf("Expected '%s' to be among the 3 cheapest products, but got %v", name, products[:3])
+		}
+	}
+
+	if err := QuickSelectBy(products, 9, func(p product) float64 { return p.price }); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestQuickSelectLargestOrdered(t *testing.T) {
+	data := randomInts(100000, 20000, 13)
+	sorted := append([]int(nil), data...)
+	sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+
+	for _, k := range []int{0, 1, 5, 50, 1000, 50000, 100000} {
+		largest := append([]int(nil), data...)
+		if err := QuickSelectLargestOrdered(largest, k); err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if !hasSameElements(largest[:k], sorted[:k]) {
+			t.Errorf("Expected QuickSelectLargestOrdered to find the largest %d elements", k)
+		}
+	}
+
+	floats := []float64{math.NaN(), 2.5, -1, math.Inf(1), math.NaN(), 7}
+	if err := QuickSelectLargestOrdered(floats, 4); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElementsFloat64(floats[:4], []float64{math.Inf(1), 7, 2.5, -1}) {
+		t.Errorf("Expected largest K elements to be '%v', but got '%v'", []float64{math.Inf(1), 7, 2.5, -1}, floats[:4])
+	}
+
+	if err := QuickSelectLargestOrdered([]string{"b", "a"}, 3); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestKthLargest(t *testing.T) {
+	data := make([]int, 10000)
+	for i := range data {
+		data[i] = (i * 7919) % 1000
+	}
+	sorted := append([]int(nil), data...)
+	sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+
+	for _, k := range []int{1, 2, 10, 1000, 5000, 9999, 10000} {
+		kth, err := KthLargest(data, k)
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if kth != sorted[k-1] {
+			t.Errorf("Expected the %d-th largest element to be %d, but got %d", k, sorted[k-1], kth)
+		}
+		if data[k-1] != kth || !hasSameElements(data[:k], sorted[:k]) {
+			t.Errorf("Expected the %d largest elements first with the %d-th at index %d", k, k, k-1)
+		}
+	}
+
+	floats := []float64{math.NaN(), 2.5, -1, math.NaN()}
+	if kth, err := KthLargest(floats, 2); err != nil || kth != -1 {
+		t.Errorf("Expected the 2nd largest element to be -1, but got %g", kth)
+	}
+
+	for _, k := range []int{0, 10001} {
+		if _, err := KthLargest(data, k); err == nil {
+			t.Errorf("Should have raised error on index outside of array length.")
+		}
+	}
+}
+
+func benchInts(b *testing.B, size, k int) {
+	benchIntsWith(b, size, k, IntQuickSelect)
+}
+
+func benchIntsWith(b *testing.B, size, k int, selection func(data []int, k int) error) {
+	b.StopTimer()
+	data := make([]int, size)
+	x := ^uint32(0)
+	for i := 0; i < b.N; i++ {
+		for n := size - 3; n <= size+3; n++ {
+			fillPseudoRandom(data, &x, n/5)
+			b.StartTimer()
+			selection(data, k)
+			b.StopTimer()
+		}
+	}
+}
+
+// Benchmarks for IntQuickSelect
+func BenchmarkIntQuickSelectSize1e2K1e1(b *testing.B) { benchInts(b, 1e2, 1e1) }
+func BenchmarkIntQuickSelectSize1e4K1e1(b *testing.B) { benchInts(b, 1e4, 1e1) }
+func BenchmarkIntQuickSelectSize1e5K1e3(b *testing.B) { benchInts(b, 1e5, 1e3) }
+func BenchmarkIntQuickSelectSize1e6K1e4(b *testing.B) { benchInts(b, 1e6, 1e4) }
+func BenchmarkIntQuickSelectSize1e7K1e3(b *testing.B) { benchInts(b, 1e7, 1e3) }
+func BenchmarkIntQuickSelectSize1e7K1e6(b *testing.B) { benchInts(b, 1e7, 1e6) }
+
+// Benchmarks for QuickSelectLargestOrdered, whose inverted comparisons share
+// the code of IntQuickSelect.
+func BenchmarkQuickSelectLargestOrderedSize1e4K1e1(b *testing.B) {
+	benchIntsWith(b, 1e4, 1e1, QuickSelectLargestOrdered[int])
+}
+func BenchmarkQuickSelectLargestOrderedSize1e5K1e3(b *testing.B) {
+	benchIntsWith(b, 1e5, 1e3, QuickSelectLargestOrdered[int])
+}
+func BenchmarkQuickSelectLargestOrderedSize1e6K1e4(b *testing.B) {
+	benchIntsWith(b, 1e6, 1e4, QuickSelectLargestOrdered[int])
+}
+func BenchmarkQuickSelectLargestOrderedSize1e7K1e6(b *testing.B) {
+	benchIntsWith(b, 1e7, 1e6, QuickSelectLargestOrdered[int])
+}
@@ -0,0 +1,156 @@
+package quickselect
+
+import (
+	"math/rand/v2"
+	"runtime"
+	"sync"
+)
+
+const parallelPartitionThreshold = 1 << 17
+
+// A span is the half-open range of indices [from, to).
+type span struct {
+	from, to int
+}
+
+/*
+QuickSelectParallel works like QuickSelect, but spreads the partitioning of
+large ranges over the given number of goroutines. Once the range that's left
+to select from is small enough the selection continues sequentially. A
+non-positive number of workers means runtime.GOMAXPROCS(0).
+
+The goroutines only ever touch disjoint ranges of indices, but they do call
+Less and Swap concurrently, so this may only be used with data whose methods
+are safe to call concurrently for different indices (which is the case for
+plain slices).
+*/
+func QuickSelectParallel(data Interface, k int, workers int) error {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	length := data.Len()
+	if k < 0 || k > length {
+		return outOfRange(k, length)
+	} else if k == 0 {
+		return nil
+	}
+
+	low, high := 0, length-1
+	for workers > 1 && high-low >= parallelPartitionThreshold {
+		pivotIndex := rand.IntN(high+1-low) + low
+		pivotIndex = parallelPartition(data, low, high, pivotIndex, workers)
+
+		if k < pivotIndex {
+			high = pivotIndex - 1
+		} else if k > pivotIndex {
+			low = pivotIndex + 1
+		} else {
+			return nil
+		}
+	}
+
+	return new(selection).randomizedSelectionFinding(data, low, high, k)
+}
+
+/*
+Does the same as partition, but with the range split into one chunk per
+worker. Every worker partitions its own chunk around the pivot, after which the
+elements that ended up on the wrong side of the overall partition index are
+swapped into place, again by all workers at once.
+*/
+func parallelPartition(data Interface, low, high, pivotIndex, workers int) int {
+	data.Swap(pivotIndex, high)
+
+	chunks := make([]span, workers)
+	splits := make([]int, workers)
+	size := (high - low + workers - 1) / workers
+	for w := range chunks {
+		from := min(low+w*size, high)
+		chunks[w] = span{from, min(from+size, high)}
+	}
+
+	var wg sync.WaitGroup
+	for w, chunk := range chunks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			splits[w] = chunk.from
+			for i := chunk.from; i < chunk.to; i++ {
+				if data.Less(i, high) {
+					data.Swap(i, splits[w])
+					splits[w]++
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	partitionIndex := low
+	for w, chunk := range chunks {
+		partitionIndex += splits[w] - chunk.from
+	}
+
+	// Elements that aren't less than the pivot but lie before the partition
+	// index need to trade places with the ones that are less than the pivot
+	// but lie after it. There are as many of the former as of the latter.
+	var left, right []span
+	misplaced := 0
+	for w, chunk := range chunks {
+		if from, to := splits[w], min(chunk.to, partitionIndex); from < to {
+			left = append(left, span{from, to})
+			misplaced += to - from
+		}
+		if from, to := max(chunk.from, partitionIndex), splits[w]; from < to {
+			right = append(right, span{from, to})
+		}
+	}
+
+	for w := 0; w < workers; w++ {
+		from, to := misplaced*w/workers, misplaced*(w+1)/workers
+		if from == to {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l, r := seekSpans(left, from), seekSpans(right, from)
+			for n := from; n < to; n++ {
+				data.Swap(l.next(), r.next())
+			}
+		}()
+	}
+	wg.Wait()
+
+	data.Swap(partitionIndex, high)
+	return partitionIndex
+}
+
+// A spanCursor walks over the indices covered by a list of spans.
+type spanCursor struct {
+	spans []span
+	index int
+}
+
+// Returns a cursor positioned at the offset-th index covered by the spans.
+func seekSpans(spans []span, offset int) *spanCursor {
+	for len(spans) > 0 && spans[0].to-spans[0].from <= offset {
+		offset -= spans[0].to - spans[0].from
+		spans = spans[1:]
+	}
+	if len(spans) == 0 {
+		return &spanCursor{}
+	}
+	return &spanCursor{spans, spans[0].from + offset}
+}
+
+// Returns the index the cursor is at and moves it forward by one.
+func (c *spanCursor) next() int {
+	index := c.index
+	c.index++
+	if c.index == c.spans[0].to && len(c.spans) > 1 {
+		c.spans = c.spans[1:]
+		c.index = c.spans[0].from
+	}
+	return index
+}
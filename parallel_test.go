@@ -0,0 +1,83 @@
+package quickselect
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestQuickSelectParallel(t *testing.T) {
+	data := IntSlice(randomInts(1e6, 100000, 99))
+	sorted := append([]int(nil), data...)
+	sort.Ints(sorted)
+
+	for _, k := range []int{1, 1000, 5e5, 999999, 1e6} {
+		for _, workers := range []int{0, 1, 3, 8} {
+			fixture := append(IntSlice(nil), data...)
+			err := QuickSelectParallel(fixture, k, workers)
+			if err != nil {
+				t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+			}
+			if !hasSameElements(fixture[:k], sorted[:k]) {
+				t.Errorf("Expected the first %d elements to be the smallest with %d workers", k, workers)
+			}
+		}
+	}
+
+	if err := QuickSelectParallel(IntSlice{1, 2}, 3, 2); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestParallelPartition(t *testing.T) {
+	data := IntSlice(randomInts(1000, 50, 3))
+
+	for _, workers := range []int{2, 3, 7, 16} {
+		fixture := append(IntSlice(nil), data...)
+		pivot := fixture[100]
+		partitionIndex := parallelPartition(fixture, 10, 989, 100, workers)
+
+		if fixture[partitionIndex] != pivot {
+			t.Errorf("Expected pivot '%d' at the partition index, but got '%d'", pivot, fixture[partitionIndex])
+		}
+		for i := 10; i < partitionIndex; i++ {
+			if fixture[i] >= pivot {
+				t.Errorf("Expected elements before the partition index to be less than '%d', but got '%d'", pivot, fixture[i])
+				break
+			}
+		}
+		for i := partitionIndex + 1; i <= 989; i++ {
+			if fixture[i] < pivot {
+				t.Errorf("Expected elements after the partition index to be at least '%d', but got '%d'", pivot, fixture[i])
+				break
+			}
+		}
+		if !hasSameElements(fixture, data) {
+			t.Errorf("Expected partitioning to only reorder the elements")
+		}
+	}
+}
+
+func benchParallel(b *testing.B, size, k int) {
+	b.StopTimer()
+	data := make(IntSlice, size)
+	x := ^uint32(0)
+	for i := 0; i < b.N; i++ {
+		for n := size - 3; n <= size+3; n++ {
+			fillPseudoRandom(data, &x, n/5)
+			b.StartTimer()
+			QuickSelectParallel(data, k, 0)
+			b.StopTimer()
+		}
+	}
+}
+
+// Benchmarks for QuickSelectParallel
+func BenchmarkQuickSelectParallelSize1e6K1e5(b *testing.B) { benchParallel(b, 1e6, 1e5) }
+
+func BenchmarkQuickSelectParallelSize1e7K1e4(b *testing.B) { benchParallel(b, 1e7, 1e4) }
+func BenchmarkQuickSelectParallelSize1e7K1e6(b *testing.B) { benchParallel(b, 1e7, 1e6) }
+
+func BenchmarkQuickSelectParallelSize1e8K1e4(b *testing.B) { benchParallel(b, 1e8, 1e4) }
+func BenchmarkQuickSelectParallelSize1e8K1e5(b *testing.B) { benchParallel(b, 1e8, 1e5) }
+func BenchmarkQuickSelectParallelSize1e8K1e6(b *testing.B) { benchParallel(b, 1e8, 1e6) }
+func BenchmarkQuickSelectParallelSize1e8K1e7(b *testing.B) { benchParallel(b, 1e8, 1e7) }
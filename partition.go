@@ -0,0 +1,53 @@
+package quickselect
+
+/*
+Partition does a three way (Dutch national flag) partitioning of the range
+[lo, hi] of the data around the element at index pivot, which must lie within
+that range. It returns lt and gt such that afterwards
+
+	data[lo:lt]     holds the elements less than the pivot,
+	data[lt:gt+1]   holds the elements equal to the pivot, and
+	data[gt+1:hi+1] holds the elements greater than the pivot.
+
+so lo <= lt <= gt <= hi. Elements outside of [lo, hi] are never touched. This is
+the building block the selection algorithms are made of, and is exported for
+building custom selection or bucketing schemes on top of. If the data
+implements Comparer, it makes a single call to Compare per element rather than
+up to two calls to Less.
+*/
+func Partition(data Interface, lo, hi, pivot int) (lt, gt int) {
+	if c, ok := data.(Comparer); ok {
+		return partitionCompare(data, c, lo, hi, pivot)
+	}
+	data.Swap(lo, pivot)
+
+	// The element at lt is always equal to the pivot, so it can stand in for
+	// it in the comparisons.
+	lt, gt = lo, hi
+	for i := lo + 1; i <= gt; {
+		if data.Less(i, lt) {
+			data.Swap(i, lt)
+			lt++
+			i++
+		} else if data.Less(lt, i) {
+			data.Swap(i, gt)
+			gt--
+		} else {
+			i++
+		}
+	}
+	return lt, gt
+}
+
+/*
+PartitionAround partitions the range [lo, hi] of the data exactly like
+Partition does, but returns the sizes of the three groups rather than where
+they start and end, for callers such as bucketing schemes and quantile sketches
+that only care about how many elements fall on either side of the pivot. The
+counts always satisfy ltCount+eqCount+gtCount == hi-lo+1, and eqCount is at
+least one since the pivot equals itself.
+*/
+func PartitionAround(data Interface, lo, hi, pivotValueIndex int) (ltCount, eqCount, gtCount int) {
+	lt, gt := Partition(data, lo, hi, pivotValueIndex)
+	return lt - lo, gt + 1 - lt, hi - gt
+}
@@ -0,0 +1,76 @@
+package quickselect
+
+import "testing"
+
+func TestPartition(t *testing.T) {
+	fixtures := []struct {
+		Array  IntSlice
+		Lo, Hi int
+		Pivot  int
+	}{
+		{IntSlice{5, 1, 5, 9, 3, 5, 7, 5, 2, 8}, 1, 8, 2},
+		{IntSlice{5, 1, 5, 9, 3, 5, 7, 5, 2, 8}, 0, 9, 9},
+		{IntSlice{4, 4, 4, 4}, 0, 3, 1},
+		{IntSlice{3, 2, 1}, 0, 2, 2},
+		{IntSlice{3, 2, 1}, 0, 2, 0},
+		{IntSlice{7}, 0, 0, 0},
+	}
+
+	for _, fixture := range fixtures {
+		original := append(IntSlice(nil), fixture.Array...)
+		pivot := fixture.Array[fixture.Pivot]
+		lt, gt := Partition(fixture.Array, fixture.Lo, fixture.Hi, fixture.Pivot)
+
+		if lt < fixture.Lo || lt > gt || gt > fixture.Hi {
+			t.Errorf("Expected %d <= lt <= gt <= %d, but got lt=%d and gt=%d", fixture.Lo, fixture.Hi, lt, gt)
+			continue
+		}
+		for i := fixture.Lo; i < lt; i++ {
+			if fixture.Array[i] >= pivot {
+				t.Errorf("Expected elements in [%d,%d) to be less than %d, but got '%v'", fixture.Lo, lt, pivot, fixture.Array)
+			}
+		}
+		for i := lt; i <= gt; i++ {
+			if fixture.Array[i] != pivot {
+				t.Errorf("Expected elements in [%d,%d] to equal %d, but got '%v'", lt, gt, pivot, fixture.Array)
+			}
+		}
+		for i := gt + 1; i <= fixture.Hi; i++ {
+			if fixture.Array[i] <= pivot {
+				t.Errorf("Expected elements in (%d,%d] to be greater than %d, but got '%v'", gt, fixture.Hi, pivot, fixture.Array)
+			}
+		}
+		for i := range original {
+			if (i < fixture.Lo || i > fixture.Hi) && fixture.Array[i] != original[i] {
+				t.Errorf("Expected elements outside of [%d,%d] to be left alone, but got '%v'", fixture.Lo, fixture.Hi, fixture.Array)
+			}
+		}
+		if !hasSameElements(fixture.Array, original) {
+			t.Errorf("Expected partitioning to only reorder the elements")
+		}
+	}
+}
+
+func TestPartitionAround(t *testing.T) {
+	fixtures := []struct {
+		Array                              IntSlice
+		Lo, Hi, Pivot                      int
+		ExpectedLt, ExpectedEq, ExpectedGt int
+	}{
+		{IntSlice{5, 1, 5, 9, 3, 5, 7, 5, 2, 8}, 0, 9, 0, 3, 4, 3},
+		{IntSlice{5, 1, 5, 9, 3, 5, 7, 5, 2, 8}, 1, 8, 3, 7, 1, 0},
+		{IntSlice{4, 4, 4, 4}, 0, 3, 1, 0, 4, 0},
+		{IntSlice{3, 2, 1}, 0, 2, 2, 0, 1, 2},
+		{IntSlice{7}, 0, 0, 0, 0, 1, 0},
+	}
+
+	for _, fixture := range fixtures {
+		lt, eq, gt := PartitionAround(fixture.Array, fixture.Lo, fixture.Hi, fixture.Pivot)
+		if lt != fixture.ExpectedLt || eq != fixture.ExpectedEq || gt != fixture.ExpectedGt {
+			t.Errorf("Expected counts %d, %d and %d, but got %d, %d and %d", fixture.ExpectedLt, fixture.ExpectedEq, fixture.ExpectedGt, lt, eq, gt)
+		}
+		if lt+eq+gt != fixture.Hi-fixture.Lo+1 {
+			t.Errorf("Expected counts to add up to %d, but got %d", fixture.Hi-fixture.Lo+1, lt+eq+gt)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package quickselect
+
+/*
+SelectWithProgress works like QuickSelect, but calls onProgress every now and
+then with the number of elements that have been ruled out so far, out of the
+total number of elements, so that a long running selection can drive a progress
+bar. An element is ruled out once it's known to be on the right side of the
+k-th position: for the randomized strategy that's everything outside of the
+range left to partition, and for the heap strategy it's everything scanned.
+
+The progress is only reported after it has advanced by at least a hundredth of
+the total, so onProgress is called no more than about a hundred times however
+large the data is. The done values passed to it never decrease, and the last
+call, made once the selection is finished, always has done equal to total.
+onProgress isn't called at all if an error is returned.
+*/
+func SelectWithProgress(data Interface, k int, onProgress func(done, total int)) error {
+	length := data.Len()
+	s := selection{progress: onProgress, total: length}
+	if err := s.quickSelectN(data, k, length); err != nil {
+		return err
+	}
+	onProgress(length, length)
+	return nil
+}
+
+// Reports the progress of a selection with remaining elements left to rule
+// out, unless it hasn't advanced by a hundredth of the total since the last
+// report.
+func (s *selection) report(remaining int) {
+	if s.progress == nil {
+		return
+	}
+	done := s.total - remaining
+	if done-s.reported < max(s.total/100, 1) {
+		return
+	}
+	s.reported = done
+	s.progress(done, s.total)
+}
@@ -0,0 +1,53 @@
+package quickselect
+
+import (
+	"math/rand/v2"
+	"sort"
+	"testing"
+)
+
+func TestSelectWithProgress(t *testing.T) {
+	data := make(IntSlice, 1000000)
+	for i := range data {
+		data[i] = rand.IntN(1000000)
+	}
+	sorted := append([]int(nil), data...)
+	sort.Ints(sorted)
+
+	for _, k := range []int{0, 10, 500000, 1000000} {
+		array := append(IntSlice(nil), data...)
+		calls, last := 0, 0
+		err := SelectWithProgress(array, k, func(done, total int) {
+			calls++
+			if total != len(array) {
+				t.Errorf("Expected the total to be %d, but got %d", len(array), total)
+			}
+			if done < last || done > total {
+				t.Errorf("Expected progress to advance within [%d,%d], but got %d", last, total, done)
+			}
+			last = done
+		})
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if !hasSameElements(array[:k], sorted[:k]) {
+			t.Errorf("Expected SelectWithProgress to find the smallest %d elements", k)
+		}
+		if last != len(array) {
+			t.Errorf("Expected the last progress report to be complete, but got %d", last)
+		}
+		if k > 0 && k < len(array) && calls < 3 {
+			t.Errorf("Expected progress to be reported along the way for k = %d, but got %d reports", k, calls)
+		}
+		if calls > 102 {
+			t.Errorf("Expected at most 102 progress reports for k = %d, but got %d", k, calls)
+		}
+	}
+
+	err := SelectWithProgress(IntSlice{1, 2}, 3, func(done, total int) {
+		t.Errorf("Expected no progress to be reported for an invalid k")
+	})
+	if err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
@@ -0,0 +1,249 @@
+package quickselect
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+/*
+Float64Quantile returns the q-th quantile of the data, for q in the range
+[0, 1], using linear interpolation between the two closest ranks. This is the
+"type 7" method from Hyndman and Fan, which is the default in both R and NumPy.
+
+Only the one or two order statistics that the quantile depends on are selected,
+so this runs in expected O(n) time instead of the O(n log n) of sorting first.
+Like Float64QuickSelect, it reorders the data, and ranks NaNs lower than any
+other value, so for {1, 2, NaN, 4} the median is 1.5. Interpolating between two
+equal infinities yields that infinity.
+*/
+func Float64Quantile(data []float64, q float64) (float64, error) {
+	if !(q >= 0 && q <= 1) {
+		return 0, fmt.Errorf("The specified quantile '%g' is outside of the range [0,1]", q)
+	}
+	if len(data) == 0 {
+		return 0, errors.New("Cannot compute the quantile of an empty slice")
+	}
+
+	h := float64(len(data)-1) * q
+	rank := int(h)
+	lower, err := Float64SelectKth(data, rank+1)
+	if err != nil {
+		return 0, err
+	}
+
+	frac := h - float64(rank)
+	if frac == 0 || rank+1 == len(data) {
+		return lower, nil
+	}
+
+	// Everything after the k-th smallest element is at least as large as it,
+	// so the next order statistic is simply the minimum of the rest.
+	upper := data[rank+1]
+	for _, f := range data[rank+2:] {
+		if f < upper || isNaN(f) && !isNaN(upper) {
+			upper = f
+		}
+	}
+
+	if upper == lower {
+		return lower, nil
+	}
+	return lower + frac*(upper-lower), nil
+}
+
+// weightedValues attaches Interface to a pair of value and weight slices, so
+// that they're reordered in lockstep.
+type weightedValues struct {
+	values, weights []float64
+}
+
+func (t weightedValues) Len() int {
+	return len(t.values)
+}
+
+func (t weightedValues) Less(i, j int) bool {
+	return Float64Slice(t.values).Less(i, j)
+}
+
+func (t weightedValues) Swap(i, j int) {
+	t.values[i], t.values[j] = t.values[j], t.values[i]
+	t.weights[i], t.weights[j] = t.weights[j], t.weights[i]
+}
+
+/*
+WeightedQuantile returns the weighted q-th quantile of the values, for q in the
+range [0, 1]. That is the smallest value for which the total weight of all the
+values less than or equal to it is at least q times the total weight. With all
+weights equal to one this is the "type 1" (inverse empirical CDF) quantile.
+
+Rather than sorting, it repeatedly partitions the values around a random pivot
+and only descends into the side that the target weight falls in, so it runs in
+expected O(n) time. The values and weights are reordered in lockstep.
+
+WeightedQuantile will raise an error if the slices differ in length, if a
+weight is negative or NaN, or if all the weights are zero.
+*/
+func WeightedQuantile(values []float64, weights []float64, q float64) (float64, error) {
+	if !(q >= 0 && q <= 1) {
+		return 0, fmt.Errorf("The specified quantile '%g' is outside of the range [0,1]", q)
+	}
+	if len(values) != len(weights) {
+		return 0, fmt.Errorf("The values and weights differ in length: %d != %d", len(values), len(weights))
+	}
+
+	total := 0.0
+	for i, w := range weights {
+		if !(w >= 0) {
+			return 0, fmt.Errorf("The weight '%g' at index %d is not a non-negative number", w, i)
+		}
+		total += w
+	}
+	if total == 0 {
+		return 0, errors.New("Cannot compute the quantile of values without any weight")
+	}
+
+	data := weightedValues{values, weights}
+	target := q * total
+	below := 0.0
+	result := values[0]
+
+	for low, high := 0, len(values)-1; low <= high; {
+		lt, gt := Partition(data, low, high, rand.IntN(high+1-low)+low)
+
+		less := sum(weights[low:lt])
+		if lt > low && below+less >= target {
+			high = lt - 1
+			continue
+		}
+
+		result = values[lt]
+		below += less + sum(weights[lt:gt+1])
+		if below >= target {
+			return result, nil
+		}
+		low = gt + 1
+	}
+
+	// Rounding errors can make the total weight come out a tiny bit short of
+	// the target, in which case the largest value is the answer.
+	return result, nil
+}
+
+/*
+Float64CountBelow returns the number of elements of the data that are strictly
+less than the threshold, in a single pass that leaves the data untouched. Like
+Float64Slice, NaNs are considered smaller than any other value, so they're
+counted unless the threshold is NaN itself. Together with selection this tells
+the rank of a value without sorting.
+*/
+func Float64CountBelow(data []float64, threshold float64) int {
+	count := 0
+	for _, f := range data {
+		if lessOrdered(f, threshold) {
+			count++
+		}
+	}
+	return count
+}
+
+/*
+Float64PercentileRank returns the percentile, between 0 and 100, at which the
+value v falls within the data. Ties are counted half, which is the "mean" or
+mid-rank convention: the rank is (less + 0.5*equal) / n * 100, where less and
+equal are the numbers of elements less than and equal to v. A value that isn't
+in the data thus gets the share of elements below it, and one that is gets the
+midpoint of its run of duplicates.
+
+Like Float64CountBelow this takes a single pass and leaves the data untouched,
+and NaNs are considered smaller than any other value. NaN is returned for empty
+data.
+*/
+func Float64PercentileRank(data []float64, v float64) float64 {
+	if len(data) == 0 {
+		return math.NaN()
+	}
+
+	less, equal := 0, 0
+	for _, f := range data {
+		if lessOrdered(f, v) {
+			less++
+		} else if !lessOrdered(v, f) {
+			equal++
+		}
+	}
+	return (float64(less) + 0.5*float64(equal)) / float64(len(data)) * 100
+}
+
+/*
+ApproxSelect returns an approximation of the k-th smallest element of the data,
+without reordering it. It finds the range of the data, counts the elements
+falling into each of the given number of equally wide buckets spanning it, and
+interpolates within the bucket holding the k-th smallest element. The result is
+off by at most the width of a bucket, (max - min) / buckets.
+
+Counting takes two sequential passes over the data and no swaps, which is
+cheaper and friendlier to the cache than partitioning when an exact answer
+isn't needed. Like Float64Slice, NaNs are considered smaller than any other
+value, so NaN is returned if k doesn't exceed the number of NaNs. An error is
+raised if the data holds an infinity, which no bucket can be finitely wide
+enough to span.
+*/
+func ApproxSelect(data Float64Slice, k, buckets int) (float64, error) {
+	if k < 1 || k > len(data) {
+		return 0, rankOutOfRange(k, len(data))
+	}
+	if buckets < 1 {
+		return 0, fmt.Errorf("The number of buckets must be positive, but got %d", buckets)
+	}
+
+	nans := 0
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, f := range data {
+		if isNaN(f) {
+			nans++
+			continue
+		}
+		lo, hi = min(lo, f), max(hi, f)
+	}
+	if k <= nans {
+		return math.NaN(), nil
+	} else if lo == hi {
+		return lo, nil
+	}
+
+	if math.IsInf(lo, 0) || math.IsInf(hi, 0) {
+		return 0, fmt.Errorf("Cannot build a histogram over the infinite range [%g,%g]", lo, hi)
+	}
+
+	// hi - lo overflows for extremes such as ±math.MaxFloat64, but half of it
+	// doesn't, so positions within the range are measured in halves.
+	half := hi/2 - lo/2
+	counts := make([]int, buckets)
+	for _, f := range data {
+		if !isNaN(f) {
+			counts[min(int((f/2-lo/2)/half*float64(buckets)), buckets-1)]++
+		}
+	}
+
+	k -= nans
+	below := 0
+	for b, count := range counts {
+		if below+count >= k {
+			offset := (float64(b) + float64(k-below)/float64(count)) / float64(buckets) * half
+			return min(lo+offset+offset, hi), nil
+		}
+		below += count
+	}
+	return hi, nil
+}
+
+// Returns the sum of the float64s.
+func sum(data []float64) float64 {
+	total := 0.0
+	for _, f := range data {
+		total += f
+	}
+	return total
+}
@@ -0,0 +1,293 @@
+package quickselect
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestFloat64Quantile(t *testing.T) {
+	fixtures := []struct {
+		Array    []float64
+		Q        float64
+		Expected float64
+	}{
+		{[]float64{1, 2, 3, 4, 5}, 0.5, 3},
+		{[]float64{1, 2, 3, 4}, 0.5, 2.5},
+		{[]float64{4, 1, 3, 2}, 0, 1},
+		{[]float64{4, 1, 3, 2}, 1, 4},
+		{[]float64{10, 20, 30, 40, 50}, 0.9, 46},
+		{[]float64{15, 20, 35, 40, 50}, 0.4, 29},
+		{[]float64{7}, 0.3, 7},
+	}
+
+	for _, fixture := range fixtures {
+		quantile, err := Float64Quantile(fixture.Array, fixture.Q)
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if math.Abs(quantile-fixture.Expected) > 1e-9 {
+			t.Errorf("Expected quantile %g to be '%g', but got '%g'", fixture.Q, fixture.Expected, quantile)
+		}
+	}
+}
+
+func TestFloat64QuantileNonFinite(t *testing.T) {
+	fixtures := []struct {
+		Array    []float64
+		Q        float64
+		Expected float64
+	}{
+		{[]float64{math.Inf(1), math.Inf(1)}, 0.5, math.Inf(1)},
+		{[]float64{math.Inf(-1), 3, math.Inf(-1)}, 0.25, math.Inf(-1)},
+		{[]float64{1, math.Inf(1)}, 0.5, math.Inf(1)},
+		{[]float64{1, 2, math.NaN(), 4}, 0.5, 1.5},
+		{[]float64{math.NaN(), 2, math.NaN(), 4}, 0.2, math.NaN()},
+	}
+
+	for _, fixture := range fixtures {
+		quantile, err := Float64Quantile(append([]float64(nil), fixture.Array...), fixture.Q)
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if quantile != fixture.Expected && !(math.IsNaN(quantile) && math.IsNaN(fixture.Expected)) {
+			t.Errorf("Expected quantile %g of '%v' to be '%g', but got '%g'", fixture.Q, fixture.Array, fixture.Expected, quantile)
+		}
+	}
+}
+
+func TestFloat64QuantileMatchesSorting(t *testing.T) {
+	data := make([]float64, 1001)
+	for i, v := range randomInts(len(data), 10000, 12345) {
+		data[i] = float64(v) / 7
+	}
+	sorted := append([]float64(nil), data...)
+	sort.Float64s(sorted)
+
+	for _, q := range []float64{0, 0.01, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999, 1} {
+		h := float64(len(sorted)-1) * q
+		lo := int(math.Floor(h))
+		expected := sorted[lo]
+		if lo+1 < len(sorted) {
+			expected += (h - float64(lo)) * (sorted[lo+1] - sorted[lo])
+		}
+
+		quantile, err := Float64Quantile(append([]float64(nil), data...), q)
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if math.Abs(quantile-expected) > 1e-9 {
+			t.Errorf("Expected quantile %g to be '%g', but got '%g'", q, expected, quantile)
+		}
+	}
+}
+
+func TestFloat64QuantileErrors(t *testing.T) {
+	for _, q := range []float64{-0.1, 1.1, math.NaN()} {
+		if _, err := Float64Quantile([]float64{1, 2, 3}, q); err == nil {
+			t.Errorf("Should have raised error on quantile '%g'.", q)
+		}
+	}
+
+	if _, err := Float64Quantile([]float64{}, 0.5); err == nil {
+		t.Errorf("Should have raised error on empty slice.")
+	}
+}
+
+func TestWeightedQuantile(t *testing.T) {
+	fixtures := []struct {
+		Values, Weights []float64
+		Q               float64
+		Expected        float64
+	}{
+		{[]float64{1, 2, 3, 4}, []float64{1, 1, 1, 1}, 0.5, 2},
+		{[]float64{1, 2, 3, 4}, []float64{1, 1, 1, 1}, 0.51, 3},
+		{[]float64{4, 3, 2, 1}, []float64{1, 1, 1, 5}, 0.5, 1},
+		{[]float64{4, 3, 2, 1}, []float64{10, 0, 0, 1}, 0.5, 4},
+		{[]float64{4, 3, 2, 1}, []float64{10, 0, 0, 1}, 0, 1},
+		{[]float64{4, 3, 2, 1}, []float64{0, 1, 1, 1}, 1, 3},
+		{[]float64{5, 5, 5, 1}, []float64{1, 1, 1, 1}, 0.3, 5},
+	}
+
+	for _, fixture := range fixtures {
+		quantile, err := WeightedQuantile(fixture.Values, fixture.Weights, fixture.Q)
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if quantile != fixture.Expected {
+			t.Errorf("Expected weighted quantile %g to be '%g', but got '%g'", fixture.Q, fixture.Expected, quantile)
+		}
+	}
+}
+
+func TestWeightedQuantileMatchesSorting(t *testing.T) {
+	values := make([]float64, 2000)
+	weights := make([]float64, len(values))
+	for i, v := range randomInts(len(values), 700, 77) {
+		values[i] = float64(v)
+	}
+	for i, w := range randomInts(len(weights), 13, 78) {
+		weights[i] = float64(w)
+	}
+
+	indices := make([]int, len(values))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.Slice(indices, func(i, j int) bool { return values[indices[i]] < values[indices[j]] })
+	total := sum(weights)
+
+	for _, q := range []float64{0, 0.1, 0.25, 0.5, 0.9, 0.999, 1} {
+		expected, cumulative := 0.0, 0.0
+		for _, i := range indices {
+			cumulative += weights[i]
+			if cumulative >= q*total && weights[i] > 0 || q == 0 {
+				expected = values[i]
+				break
+			}
+		}
+
+		quantile, err := WeightedQuantile(append([]float64(nil), values...), append([]float64(nil), weights...), q)
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if quantile != expected {
+			t.Errorf("Expected weighted quantile %g to be '%g', but got '%g'", q, expected, quantile)
+		}
+	}
+}
+
+func TestWeightedQuantileErrors(t *testing.T) {
+	fixtures := []struct {
+		Values, Weights []float64
+		Q               float64
+	}{
+		{[]float64{1, 2}, []float64{1}, 0.5},
+		{[]float64{1, 2}, []float64{1, -1}, 0.5},
+		{[]float64{1, 2}, []float64{1, math.NaN()}, 0.5},
+		{[]float64{1, 2}, []float64{0, 0}, 0.5},
+		{[]float64{}, []float64{}, 0.5},
+		{[]float64{1, 2}, []float64{1, 1}, 2},
+	}
+
+	for _, fixture := range fixtures {
+		if _, err := WeightedQuantile(fixture.Values, fixture.Weights, fixture.Q); err == nil {
+			t.Errorf("Should have raised error on values '%v' with weights '%v'.", fixture.Values, fixture.Weights)
+		}
+	}
+}
+
+func TestFloat64CountBelow(t *testing.T) {
+	nan := math.NaN()
+	fixtures := []struct {
+		Array     []float64
+		Threshold float64
+		Expected  int
+	}{
+		{[]float64{16.1, 29.3, -11.5, 25.3, 28.8, -14.7, 10.5}, 16.1, 3},
+		{[]float64{16.1, 29.3, -11.5, 25.3, 28.8, -14.7, 10.5}, -20, 0},
+		{[]float64{16.1, 29.3, -11.5, 25.3, 28.8, -14.7, 10.5}, math.Inf(1), 7},
+		{[]float64{2, 2, 2, 1}, 2, 1},
+		{[]float64{nan, 3, nan, -1}, 0, 3},
+		{[]float64{nan, 3, nan, -1}, nan, 0},
+		{[]float64{}, 1, 0},
+	}
+
+	for _, fixture := range fixtures {
+		if count := Float64CountBelow(fixture.Array, fixture.Threshold); count != fixture.Expected {
+			t.Errorf("Expected %d elements of %v below '%g', but got %d", fixture.Expected, fixture.Array, fixture.Threshold, count)
+		}
+	}
+}
+
+func TestFloat64PercentileRank(t *testing.T) {
+	latencies := []float64{120, 35, 80, 240, 15, 95, 60, 20, 240, 180}
+	fixtures := []struct {
+		Value    float64
+		Expected float64
+	}{
+		{10, 0},
+		{15, 5},
+		{100, 60},
+		{240, 90},
+		{500, 100},
+	}
+
+	for _, fixture := range fixtures {
+		if rank := Float64PercentileRank(latencies, fixture.Value); math.Abs(rank-fixture.Expected) > 1e-9 {
+			t.Errorf("Expected percentile rank of '%g' to be '%g', but got '%g'", fixture.Value, fixture.Expected, rank)
+		}
+	}
+
+	if rank := Float64PercentileRank([]float64{math.NaN(), 1, 2, 3}, 0); rank != 25 {
+		t.Errorf("Expected percentile rank of '0' to be '25', but got '%g'", rank)
+	}
+	if rank := Float64PercentileRank(nil, 1); !math.IsNaN(rank) {
+		t.Errorf("Expected percentile rank in empty data to be NaN, but got '%g'", rank)
+	}
+}
+
+func TestApproxSelect(t *testing.T) {
+	data := make(Float64Slice, 10000)
+	for i := range data {
+		data[i] = float64((i * 7919) % len(data))
+	}
+	data[123] = 1e4 + 0.5
+	original := append(Float64Slice(nil), data...)
+	sorted := append([]float64(nil), data...)
+	sort.Float64s(sorted)
+
+	for _, buckets := range []int{1, 10, 100, 1000} {
+		width := (sorted[len(sorted)-1] - sorted[0]) / float64(buckets)
+		for _, k := range []int{1, 50, 2500, 5000, 9999, 10000} {
+			threshold, err := ApproxSelect(data, k, buckets)
+			if err != nil {
+				t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+			}
+			if math.Abs(threshold-sorted[k-1]) > width {
+				t.Errorf("Expected k = %d with %d buckets to be within %g of '%g', but got '%g'", k, buckets, width, sorted[k-1], threshold)
+			}
+		}
+	}
+	for i := range data {
+		if data[i] != original[i] {
+			t.Errorf("Expected data to be left untouched")
+			break
+		}
+	}
+}
+
+func TestApproxSelectSpecialValues(t *testing.T) {
+	nan := math.NaN()
+	if threshold, err := ApproxSelect(Float64Slice{3, nan, 1}, 1, 10); err != nil || !math.IsNaN(threshold) {
+		t.Errorf("Expected NaN for the smallest element, but got '%g'", threshold)
+	}
+	if threshold, err := ApproxSelect(Float64Slice{3, nan, 1}, 3, 10); err != nil || threshold != 3 {
+		t.Errorf("Expected '3' for the largest element, but got '%g'", threshold)
+	}
+	if threshold, err := ApproxSelect(Float64Slice{4, 4, 4}, 2, 10); err != nil || threshold != 4 {
+		t.Errorf("Expected '4' for data of a single value, but got '%g'", threshold)
+	}
+
+	extremes := Float64Slice{math.MaxFloat64, -math.MaxFloat64, 0, math.MaxFloat64 / 2}
+	for _, buckets := range []int{1, 2, 10} {
+		approx, err := ApproxSelect(extremes, 3, buckets)
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		// Halved on both sides, since a bucket is 2*MaxFloat64/buckets wide.
+		if math.Abs(approx/2-math.MaxFloat64/4) > math.MaxFloat64/float64(buckets) {
+			t.Errorf("Expected ApproxSelect to be within a bucket of %g with %d buckets, but got %g", math.MaxFloat64/2, buckets, approx)
+		}
+	}
+
+	if _, err := ApproxSelect(Float64Slice{math.Inf(-1), 0}, 1, 10); err == nil {
+		t.Errorf("Should have raised error on an infinite range.")
+	}
+	if _, err := ApproxSelect(Float64Slice{1, 2}, 1, 0); err == nil {
+		t.Errorf("Should have raised error on a non-positive number of buckets.")
+	}
+	if _, err := ApproxSelect(Float64Slice{1, 2}, 3, 10); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
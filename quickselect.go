@@ -9,25 +9,130 @@ heap implementations).
 package quickselect
 
 import (
+	"context"
 	"fmt"
+	"iter"
+	"math/bits"
 	"math/rand/v2"
+	"sort"
+	"time"
 )
 
-const (
-	partitionThreshold            = 8
-	naiveSelectionLengthThreshold = 100
-	naiveSelectionThreshold       = 10
-	heapSelectionKRatio           = 0.001
-	heapSelectionThreshold        = 1e3
+/*
+The crossover points between the selection strategies. The defaults suit
+typical desktop and server CPUs, but the sweet spots differ from machine to
+machine, so they can be tuned for the host at hand. They're read by every
+selection, so set them once before selecting; changing them while selections
+are running is a data race.
+*/
+var (
+	// PartitionThreshold is the size of a range at or below which the
+	// randomized selection stops partitioning and insertion sorts the range.
+	PartitionThreshold = 8
+	// NaiveSelectionLengthThreshold and NaiveSelectionThreshold are the
+	// largest length and k for which the naive strategy is used.
+	NaiveSelectionLengthThreshold = 100
+	NaiveSelectionThreshold       = 10
+	// HeapSelectionKRatio and HeapSelectionThreshold are the largest ratio
+	// of k to the length, and the largest k, for which the heap strategy is
+	// used.
+	HeapSelectionKRatio    = 0.001
+	HeapSelectionThreshold = 1000
 )
 
+const checkpointInterval = 1 << 16
+
+/*
+A selection carries the optional, per-call state of a single selection through
+the selection strategies. The zero value selects without any of the extras.
+*/
+type selection struct {
+	// ctx, when set, is checked every now and then so that long running
+	// selections can be abandoned.
+	ctx context.Context
+	// work counts the elements processed since the context was last checked.
+	work int
+	// rng, when set, replaces the global source of randomness for picking
+	// pivots.
+	rng *rand.Rand
+	// buf is scratch space for the indices kept by the naive and heap
+	// strategies, which a Selector holds on to between selections.
+	buf []int
+	// limit is the number of unbalanced partitions tolerated before the
+	// randomized strategy switches to fallback. Zero means bits.Len(n), and a
+	// negative limit switches right away.
+	limit    int
+	fallback Fallback
+	// insertion, when non-zero, replaces PartitionThreshold as the size of a
+	// range at or below which it's insertion sorted.
+	insertion int
+	// swapCost, when known, decides between the randomized and the heap
+	// strategy instead of the data's length and k.
+	swapCost SwapCost
+	// stats, when set, is filled in with what the selection went through.
+	stats *Stats
+	// progress, when set, is told how many of the total elements have been
+	// ruled out so far. reported is how many it was last told about.
+	progress        func(done, total int)
+	total, reported int
+	// start is the first index the randomized strategy may look at. The
+	// elements before it are only known to be no larger than the range being
+	// selected from if they were placed there by an earlier selection.
+	start int
+}
+
+// Returns the size of a range at or below which it's insertion sorted rather
+// than partitioned.
+func (s *selection) insertionThreshold() int {
+	if s.insertion != 0 {
+		return s.insertion
+	}
+	return PartitionThreshold
+}
+
+// Returns scratch space for k indices, reusing the buffer when it's big enough.
+func (s *selection) scratch(k int) []int {
+	if cap(s.buf) < k {
+		s.buf = make([]int, k)
+	}
+	return s.buf[:k]
+}
+
+// Returns a random int in the range [0, n).
+func (s *selection) intN(n int) int {
+	if s.rng != nil {
+		return s.rng.IntN(n)
+	}
+	return rand.IntN(n)
+}
+
+/*
+Accounts for work elements having been processed and reports the context's
+error roughly once every checkpointInterval elements, so that cancellation is
+noticed promptly without paying for a context check on every step.
+*/
+func (s *selection) checkpoint(work int) error {
+	if s.ctx == nil {
+		return nil
+	}
+	s.work += work
+	if s.work < checkpointInterval {
+		return nil
+	}
+	s.work = 0
+	return s.ctx.Err()
+}
+
 /*
 A type, typically a collection, which satisfies quickselect.Interface can be
 used as data in the QuickSelect method. The interface is the same as the
 interface required by Go's canonical sorting package (sort.Interface).
 
 Note that the methods require that the elements of the collection be enumerated
-by an integer index.
+by an integer index. The collection must not be resized while a selection runs:
+every function in this package calls Len once up front and keeps to that length
+throughout, so a collection that grows won't be read out of bounds, but one that
+shrinks can't be helped.
 */
 type Interface interface {
 	// Len is the number of elements in the collection
@@ -39,6 +144,18 @@ type Interface interface {
 	Swap(i, j int)
 }
 
+type prefix struct {
+	// This embedded Interface permits prefix to use the methods of another
+	// Interface implementation.
+	Interface
+	n int
+}
+
+// Len returns the length of the prefix rather than of the embedded Interface.
+func (p prefix) Len() int {
+	return p.n
+}
+
 type reverse struct {
 	// This embedded Interface permits Reverse to use the methods of
 	// another Interface implementation.
@@ -54,6 +171,26 @@ func Reverse(data Interface) Interface {
 	return &reverse{data}
 }
 
+// lessSwap implements Interface on top of a length and a pair of closures, so
+// that QuickSelectFunc can run on collections without a named type.
+type lessSwap struct {
+	n    int
+	less func(i, j int) bool
+	swap func(i, j int)
+}
+
+func (t lessSwap) Len() int {
+	return t.n
+}
+
+func (t lessSwap) Less(i, j int) bool {
+	return t.less(i, j)
+}
+
+func (t lessSwap) Swap(i, j int) {
+	t.swap(i, j)
+}
+
 // The IntSlice type attaches the QuickSelect interface to an array of ints. It
 // implements Interface so that you can call QuickSelect(k) on any IntSlice.
 type IntSlice []int
@@ -74,7 +211,14 @@ func (t IntSlice) Swap(i, j int) {
 // IntSlice are the k smallest elements in the slice. This is a convenience
 // method for QuickSelect
 func (t IntSlice) QuickSelect(k int) error {
-	return QuickSelect(t, k)
+	return orderedQuickSelect(t, k)
+}
+
+// QuickSelectLargest(k) mutates the IntSlice so that the first k elements in
+// the IntSlice are the k largest elements in the slice. This is a convenience
+// method for QuickSelect on Reverse(IntSlice).
+func (t IntSlice) QuickSelectLargest(k int) error {
+	return orderedSelect(t, k, true)
 }
 
 // The Float64Slice type attaches the QuickSelect interface to an array of
@@ -98,7 +242,48 @@ func (t Float64Slice) Swap(i, j int) {
 // Float64Slice are the k smallest elements in the slice. This is a convenience
 // method for QuickSelect
 func (t Float64Slice) QuickSelect(k int) error {
-	return QuickSelect(t, k)
+	return orderedQuickSelect(t, k)
+}
+
+// QuickSelectLargest(k) mutates the Float64Slice so that the first k elements
+// in the Float64Slice are the k largest elements in the slice. This is a
+// convenience method for QuickSelect on Reverse(Float64Slice). NaNs are
+// considered smaller than any other value, so they are selected last.
+func (t Float64Slice) QuickSelectLargest(k int) error {
+	return orderedSelect(t, k, true)
+}
+
+// The Float32Slice type attaches the QuickSelect interface to an array of
+// float32s. It implements Interface so that you can call QuickSelect(k) on any
+// Float32Slice. Like Float64Slice, it considers NaNs smaller than any other
+// value.
+type Float32Slice []float32
+
+func (t Float32Slice) Len() int {
+	return len(t)
+}
+
+func (t Float32Slice) Less(i, j int) bool {
+	return t[i] < t[j] || isNaNOrdered(t[i]) && !isNaNOrdered(t[j])
+}
+
+func (t Float32Slice) Swap(i, j int) {
+	t[i], t[j] = t[j], t[i]
+}
+
+// QuickSelect(k) mutates the Float32Slice so that the first k elements in the
+// Float32Slice are the k smallest elements in the slice. This is a convenience
+// method for QuickSelect
+func (t Float32Slice) QuickSelect(k int) error {
+	return orderedQuickSelect(t, k)
+}
+
+// QuickSelectLargest(k) mutates the Float32Slice so that the first k elements
+// in the Float32Slice are the k largest elements in the slice. This is a
+// convenience method for QuickSelect on Reverse(Float32Slice). NaNs are
+// considered smaller than any other value, so they are selected last.
+func (t Float32Slice) QuickSelectLargest(k int) error {
+	return orderedSelect(t, k, true)
 }
 
 // The StringSlice type attaches the QuickSelect interface to an array of
@@ -122,9 +307,199 @@ func (t StringSlice) Swap(i, j int) {
 // StringSlice are the k smallest elements in the slice. This is a convenience
 // method for QuickSelect
 func (t StringSlice) QuickSelect(k int) error {
+	return orderedQuickSelect(t, k)
+}
+
+// QuickSelectLargest(k) mutates the StringSlice so that the first k elements in
+// the StringSlice are the k largest elements in the slice. This is a
+// convenience method for QuickSelect on Reverse(StringSlice).
+func (t StringSlice) QuickSelectLargest(k int) error {
+	return orderedSelect(t, k, true)
+}
+
+// The Int32Slice type attaches the QuickSelect interface to an array of int32s. It
+// implements Interface so that you can call QuickSelect(k) on any Int32Slice.
+type Int32Slice []int32
+
+func (t Int32Slice) Len() int {
+	return len(t)
+}
+
+func (t Int32Slice) Less(i, j int) bool {
+	return t[i] < t[j]
+}
+
+func (t Int32Slice) Swap(i, j int) {
+	t[i], t[j] = t[j], t[i]
+}
+
+// QuickSelect(k) mutates the Int32Slice so that the first k elements in the
+// Int32Slice are the k smallest elements in the slice. This is a convenience
+// method for QuickSelect
+func (t Int32Slice) QuickSelect(k int) error {
+	return orderedQuickSelect(t, k)
+}
+
+// QuickSelectLargest(k) mutates the Int32Slice so that the first k elements in
+// the Int32Slice are the k largest elements in the slice. This is a convenience
+// method for QuickSelect on Reverse(Int32Slice).
+func (t Int32Slice) QuickSelectLargest(k int) error {
+	return orderedSelect(t, k, true)
+}
+
+// The Int64Slice type attaches the QuickSelect interface to an array of int64s. It
+// implements Interface so that you can call QuickSelect(k) on any Int64Slice.
+type Int64Slice []int64
+
+func (t Int64Slice) Len() int {
+	return len(t)
+}
+
+func (t Int64Slice) Less(i, j int) bool {
+	return t[i] < t[j]
+}
+
+func (t Int64Slice) Swap(i, j int) {
+	t[i], t[j] = t[j], t[i]
+}
+
+// QuickSelect(k) mutates the Int64Slice so that the first k elements in the
+// Int64Slice are the k smallest elements in the slice. This is a convenience
+// method for QuickSelect
+func (t Int64Slice) QuickSelect(k int) error {
+	return orderedQuickSelect(t, k)
+}
+
+// QuickSelectLargest(k) mutates the Int64Slice so that the first k elements in
+// the Int64Slice are the k largest elements in the slice. This is a convenience
+// method for QuickSelect on Reverse(Int64Slice).
+func (t Int64Slice) QuickSelectLargest(k int) error {
+	return orderedSelect(t, k, true)
+}
+
+// The UintSlice type attaches the QuickSelect interface to an array of uints. It
+// implements Interface so that you can call QuickSelect(k) on any UintSlice.
+type UintSlice []uint
+
+func (t UintSlice) Len() int {
+	return len(t)
+}
+
+func (t UintSlice) Less(i, j int) bool {
+	return t[i] < t[j]
+}
+
+func (t UintSlice) Swap(i, j int) {
+	t[i], t[j] = t[j], t[i]
+}
+
+// QuickSelect(k) mutates the UintSlice so that the first k elements in the
+// UintSlice are the k smallest elements in the slice. This is a convenience
+// method for QuickSelect
+func (t UintSlice) QuickSelect(k int) error {
+	return orderedQuickSelect(t, k)
+}
+
+// QuickSelectLargest(k) mutates the UintSlice so that the first k elements in
+// the UintSlice are the k largest elements in the slice. This is a convenience
+// method for QuickSelect on Reverse(UintSlice).
+func (t UintSlice) QuickSelectLargest(k int) error {
+	return orderedSelect(t, k, true)
+}
+
+// The Uint64Slice type attaches the QuickSelect interface to an array of uint64s. It
+// implements Interface so that you can call QuickSelect(k) on any Uint64Slice.
+type Uint64Slice []uint64
+
+func (t Uint64Slice) Len() int {
+	return len(t)
+}
+
+func (t Uint64Slice) Less(i, j int) bool {
+	return t[i] < t[j]
+}
+
+func (t Uint64Slice) Swap(i, j int) {
+	t[i], t[j] = t[j], t[i]
+}
+
+// QuickSelect(k) mutates the Uint64Slice so that the first k elements in the
+// Uint64Slice are the k smallest elements in the slice. This is a convenience
+// method for QuickSelect
+func (t Uint64Slice) QuickSelect(k int) error {
+	return orderedQuickSelect(t, k)
+}
+
+// QuickSelectLargest(k) mutates the Uint64Slice so that the first k elements in
+// the Uint64Slice are the k largest elements in the slice. This is a
+// convenience method for QuickSelect on Reverse(Uint64Slice).
+func (t Uint64Slice) QuickSelectLargest(k int) error {
+	return orderedSelect(t, k, true)
+}
+
+// The ByteSlice type attaches the QuickSelect interface to an array of bytes.
+// It implements Interface so that you can call QuickSelect(k) on any
+// ByteSlice. Bytes are compared as unsigned values, so 0x80 sorts after 0x7F.
+type ByteSlice []byte
+
+func (t ByteSlice) Len() int {
+	return len(t)
+}
+
+func (t ByteSlice) Less(i, j int) bool {
+	return t[i] < t[j]
+}
+
+func (t ByteSlice) Swap(i, j int) {
+	t[i], t[j] = t[j], t[i]
+}
+
+// QuickSelect(k) mutates the ByteSlice so that the first k elements in the
+// ByteSlice are the k smallest elements in the slice. This is a convenience
+// method for QuickSelect
+func (t ByteSlice) QuickSelect(k int) error {
+	return orderedQuickSelect(t, k)
+}
+
+// QuickSelectLargest(k) mutates the ByteSlice so that the first k elements in
+// the ByteSlice are the k largest elements in the slice. This is a convenience
+// method for QuickSelect on Reverse(ByteSlice).
+func (t ByteSlice) QuickSelectLargest(k int) error {
+	return orderedSelect(t, k, true)
+}
+
+// The TimeSlice type attaches the QuickSelect interface to an array of
+// time.Times. It implements Interface so that you can call QuickSelect(k) on
+// any TimeSlice. Times are compared with Before, so the smallest elements are
+// the earliest ones.
+type TimeSlice []time.Time
+
+func (t TimeSlice) Len() int {
+	return len(t)
+}
+
+func (t TimeSlice) Less(i, j int) bool {
+	return t[i].Before(t[j])
+}
+
+func (t TimeSlice) Swap(i, j int) {
+	t[i], t[j] = t[j], t[i]
+}
+
+// QuickSelect(k) mutates the TimeSlice so that the first k elements in the
+// TimeSlice are the k earliest times in the slice. This is a convenience
+// method for QuickSelect
+func (t TimeSlice) QuickSelect(k int) error {
 	return QuickSelect(t, k)
 }
 
+// QuickSelectLargest(k) mutates the TimeSlice so that the first k elements in
+// the TimeSlice are the k latest times in the slice. This is a convenience
+// method for QuickSelect on Reverse(TimeSlice).
+func (t TimeSlice) QuickSelectLargest(k int) error {
+	return QuickSelect(Reverse(t), k)
+}
+
 // isNaN is a copy of math.IsNaN to avoid a dependency on the math package.
 func isNaN(f float64) bool {
 	return f != f
@@ -138,28 +513,78 @@ in expected O(n) time.
 The algorithm works by finding a random pivot element, and making sure all the
 elements to the left are less than the pivot element and vice versa for
 elements on the right. Recursing on this solves the selection algorithm.
+
+No element before low may be greater than any element in the range [low, high],
+which holds for every range a partition leaves behind. So if the pivot isn't
+greater than the element right before the range, it's the smallest value in the
+range, and everything equal to it is gathered at the front of the range
+instead. Should k fall among those the selection is done, which finishes a
+range made up of a single repeated value in one pass, whatever k is.
+
+Every partition that keeps more than 7/8 of the range counts against the
+selection's limit. Once the limit is exhausted the rest of the range is handed
+to the fallback, which bounds the running time even for adversarial inputs.
 */
-func randomizedSelectionFinding(data Interface, low, high, k int) {
-	var pivotIndex int
+func (s *selection) randomizedSelectionFinding(data Interface, low, high, k int) error {
+	var pivotIndex, size, depth int
+
+	limit := s.limit
+	if limit == 0 {
+		limit = bits.Len(uint(high + 1 - low))
+	}
+	threshold := s.insertionThreshold()
 
 	for {
 		if low >= high {
-			return
-		} else if high-low <= partitionThreshold {
+			return nil
+		} else if high-low <= threshold {
 			insertionSort(data, low, high+1)
-			return
+			return nil
+		} else if limit < 0 {
+			s.fallbackSelectionFinding(data, low, high, k)
+			return nil
 		}
 
-		pivotIndex = rand.IntN(high+1-low) + low
-		pivotIndex = partition(data, low, high, pivotIndex)
+		size = high + 1 - low
+		pivotIndex = s.intN(size) + low
+		equal := low > s.start && !data.Less(low-1, pivotIndex)
+		if equal {
+			pivotIndex = partitionEqual(data, low, high, pivotIndex)
+		} else {
+			pivotIndex = partition(data, low, high, pivotIndex)
+		}
+		if err := s.checkpoint(size); err != nil {
+			return err
+		}
+		if depth++; s.stats != nil {
+			s.stats.Partitions++
+			s.stats.MaxDepth = max(s.stats.MaxDepth, depth)
+		}
 
-		if k < pivotIndex {
+		if k == pivotIndex || equal && k < pivotIndex {
+			return nil
+		} else if k < pivotIndex {
 			high = pivotIndex - 1
-		} else if k > pivotIndex {
-			low = pivotIndex + 1
 		} else {
-			return
+			low = pivotIndex + 1
 		}
+		if high+1-low > size-size/8 {
+			limit--
+		}
+		s.report(high + 1 - low)
+	}
+}
+
+// Finishes the selection in the range [low, high] with the selection's fallback.
+func (s *selection) fallbackSelectionFinding(data Interface, low, high, k int) {
+	if s.stats != nil {
+		s.stats.Fallback = true
+	}
+	switch s.fallback {
+	case MedianOfMediansFallback:
+		deterministicSelectionFinding(data, low, high, k)
+	default:
+		heapSort(data, low, high+1)
 	}
 }
 
@@ -172,19 +597,49 @@ func insertionSort(data Interface, a, b int) {
 	}
 }
 
+// Heapsort of the range [a, b)
+func heapSort(data Interface, a, b int) {
+	n := b - a
+	for i := (n - 1) / 2; i >= 0; i-- {
+		siftDown(data, i, n, a)
+	}
+	for i := n - 1; i > 0; i-- {
+		data.Swap(a, a+i)
+		siftDown(data, 0, i, a)
+	}
+}
+
+// Restores the max-heap property of the heap rooted at first, which holds n
+// elements, by moving the element at root down.
+func siftDown(data Interface, root, n, first int) {
+	for {
+		child := 2*root + 1
+		if child >= n {
+			return
+		}
+		if child+1 < n && data.Less(first+child, first+child+1) {
+			child++
+		}
+		if !data.Less(first+root, first+child) {
+			return
+		}
+		data.Swap(first+root, first+child)
+		root = child
+	}
+}
+
 /*
 This method does a run over all of the data keeps a list of the k smallest
 indices that it has seen so far. At the end, it swaps those k elements and
 moves them to the front.
 */
-func naiveSelectionFinding(data Interface, k int) {
-	smallestIndices := make([]int, k)
+func (s *selection) naiveSelectionFinding(data Interface, k, length int) {
+	smallestIndices := s.scratch(k)
 	for i := 0; i < k; i++ {
 		smallestIndices[i] = i
 	}
 	resetLargestIndex(smallestIndices, data)
 
-	length := data.Len()
 	for i := k; i < length; i++ {
 		if data.Less(i, smallestIndices[k-1]) {
 			smallestIndices[k-1] = i
@@ -192,7 +647,7 @@ func naiveSelectionFinding(data Interface, k int) {
 		}
 	}
 
-	insertionSort(IntSlice(smallestIndices), 0, k)
+	orderedInsertionSort(smallestIndices, 0, k)
 	for i := 0; i < k; i++ {
 		data.Swap(i, smallestIndices[i])
 	}
@@ -200,7 +655,10 @@ func naiveSelectionFinding(data Interface, k int) {
 
 /*
 Takes the largest index in `indices` according to the data Interface and places
-it at the end of the indices array.
+it at the end of the indices array. Among equal elements the first one found is
+taken, which is as good as any of them: all the naive strategy relies on is
+that nothing in `indices` is larger than the last one, which holds for all-equal
+data as well.
 */
 func resetLargestIndex(indices []int, data Interface) {
 	var largestIndex = 0
@@ -222,8 +680,9 @@ Helper function for the selection algorithm. Returns the partitionIndex.
 It goes through all elements between low and high and makes sure that the
 elements in the range [low, partitionIndex) are less than the element that was
 originally in the pivotIndex and that the elements in the range
-[paritionIndex + 1, high] are greater than the element originally in the
-pivotIndex.
+[partitionIndex + 1, high] are greater than or equal to the element originally
+in the pivotIndex. Both low and high are inclusive, and the pivot is parked at
+high while scanning, so it lands at partitionIndex even when that is low or high.
 */
 func partition(data Interface, low, high, pivotIndex int) int {
 	partitionIndex := low
@@ -238,6 +697,24 @@ func partition(data Interface, low, high, pivotIndex int) int {
 	return partitionIndex
 }
 
+/*
+Helper function for the selection algorithm, for a pivot that's the smallest
+value in the range [low, high]. It moves all the elements equal to the pivot to
+the front of the range and returns the index of the last of them, so that the
+elements after that index are all greater than the pivot.
+*/
+func partitionEqual(data Interface, low, high, pivotIndex int) int {
+	partitionIndex := low
+	data.Swap(pivotIndex, low)
+	for i := low + 1; i <= high; i++ {
+		if !data.Less(low, i) {
+			partitionIndex++
+			data.Swap(i, partitionIndex)
+		}
+	}
+	return partitionIndex
+}
+
 func heapInit(data Interface, heap []int) {
 	// Heapify process
 	n := len(heap)
@@ -248,10 +725,10 @@ func heapInit(data Interface, heap []int) {
 
 func heapDown(data Interface, heap []int, i, n int) {
 	for {
-		j1 := 2*i + 1
-		if j1 >= n || j1 < 0 { // j1 < 0 after int overflow
+		if i >= n/2 || i < 0 { // i has no children, so 2*i+1 can't overflow
 			break
 		}
+		j1 := 2*i + 1
 		j := j1 // left child
 		if j2 := j1 + 1; j2 < n && data.Less(heap[j1], heap[j2]) {
 			j = j2 // right child
@@ -269,25 +746,31 @@ This method implements the heap strategy for selecting the smallest k elements.
 It keeps a max-heap of the smallest k elements seen so far as we iterate over
 all of the elements. It adds a new element and pops the largest element.
 */
-func heapSelectionFinding(data Interface, k int) {
-	heap := make([]int, k)
+func (s *selection) heapSelectionFinding(data Interface, k, length int) error {
+	heap := s.scratch(k)
 	for i := 0; i < k; i++ {
 		heap[i] = i
 	}
 	heapInit(data, heap)
 
-	length := data.Len()
 	for i := k; i < length; i++ {
+		if (i-k)%checkpointInterval == 0 {
+			if err := s.checkpoint(checkpointInterval); err != nil {
+				return err
+			}
+			s.report(length - i)
+		}
 		if data.Less(i, heap[0]) {
 			heap[0] = i
 			heapDown(data, heap, 0, k)
 		}
 	}
 
-	insertionSort(IntSlice(heap), 0, k)
+	sort.Ints(heap)
 	for i := 0; i < k; i++ {
 		data.Swap(i, heap[i])
 	}
+	return nil
 }
 
 /*
@@ -299,44 +782,863 @@ QuickSelect implements Hoare's Selection Algorithm and runs in O(n) time, so it
 is asymptotically faster than sorting or other heap-like implementations for
 finding the smallest k elements in a data structure.
 
-Note that k must be in the range [0, data.Len()), otherwise the QuickSelect
-method will raise an error.
+Note that k must be in the range [0, data.Len()], otherwise the QuickSelect
+method will raise an error. When k is 0 nothing is selected, and when k is
+data.Len() every element is among the smallest k, so in both cases the data is
+left as it is.
 */
 func QuickSelect(data Interface, k int) error {
+	return new(selection).quickSelect(data, k)
+}
+
+/*
+Select swaps elements in the data provided just like QuickSelect does, and
+returns the bounds of the block holding the k smallest elements, which is
+data[lo:hi]. The block always starts at the beginning of the data and holds
+exactly k elements, so lo is 0 and hi is k. For k == 0 the block is empty.
+
+If the k-th smallest element is tied with elements that didn't make it into
+the block (i.e. duplicates straddle the boundary), it is unspecified which of
+the equal elements end up inside the block and which after it. Either way no
+element after the block is smaller than any element inside of it.
+
+Unlike QuickSelect, Select panics if k is outside of the range [0, data.Len()].
+*/
+func Select(data Interface, k int) (lo, hi int) {
+	if err := QuickSelect(data, k); err != nil {
+		panic(err)
+	}
+	return 0, k
+}
+
+/*
+SelectInterface is the bridge for the wrapper types of the standard library's
+sort package, such as sort.IntSlice, sort.Float64Slice and sort.StringSlice, or
+anything else that already implements sort.Interface. Since its methods are the
+same as Interface's, any sort.Interface can be selected on directly, and this
+works exactly like Select.
+
+The returned bounds are those of the block holding the k smallest elements,
+data[lo:hi], which always starts at the beginning of the data: lo is 0 and hi
+is k, and the block is empty for k == 0. The elements within the block, and
+those after it, are in no particular order. SelectInterface panics if k is
+outside of the range [0, data.Len()].
+*/
+func SelectInterface(data sort.Interface, k int) (lo, hi int) {
+	return Select(data, k)
+}
+
+/*
+SelectThreshold works like Select, and additionally reports whether the cut
+between the block and the rest of the data falls inside a run of duplicates.
+The k-th smallest element is placed at index hi-1 and, unless the block spans
+all of the data, the (k+1)-th smallest at index hi, so the threshold values on
+either side of the cut can be read without scanning the data again. pivotIsTie
+is true when those two elements are equal.
+
+Like Select, SelectThreshold panics if k is outside of the range
+[0, data.Len()]. For k == 0 the block is empty and there's no cut to tie at.
+*/
+func SelectThreshold(data Interface, k int) (lo, hi int, pivotIsTie bool) {
+	length := data.Len()
+	if err := new(selection).quickSelectN(data, k, length); err != nil {
+		panic(err)
+	}
+	lo, hi = 0, k
+	if k == 0 || k == length {
+		if k > 0 {
+			placeKth(data, k)
+		}
+		return lo, hi, false
+	}
+	placeKth(data, k)
+
+	smallest := k
+	for i := k + 1; i < length; i++ {
+		if data.Less(i, smallest) {
+			smallest = i
+		}
+	}
+	data.Swap(k, smallest)
+	return lo, hi, !data.Less(k-1, k)
+}
+
+/*
+A Split describes how SelectSplit divided the data around the k-th smallest
+element: data[:Lo] holds the elements less than it, data[Lo:Hi] the elements
+equal to it, and data[Hi:] the elements greater than it.
+
+Lo <= k <= Hi, so with duplicates of the k-th smallest element straddling the
+boundary, the run of equal elements reaches past the k smallest.
+*/
+type Split struct {
+	Lo, Hi int
+}
+
+/*
+SelectSplit swaps elements in the data provided so that the first k elements
+are the smallest k elements, just like Select, and additionally gathers all the
+elements equal to the k-th smallest around the boundary, so that the parts
+before, among and after the ties can each be processed on their own. Gathering
+them takes one more pass over the data. SelectSplit panics if k is outside of
+the range [0, data.Len()]. For k == 0 there's no k-th smallest element, and an
+empty Split is returned.
+*/
+func SelectSplit(data Interface, k int) Split {
+	length := data.Len()
+	if err := new(selection).quickSelectN(data, k, length); err != nil {
+		panic(err)
+	}
+	if k == 0 {
+		return Split{}
+	}
+	placeKth(data, k)
+
+	lt, _ := Partition(data, 0, k-1, k-1)
+	hi := k
+	for i := k; i < length; i++ {
+		if !data.Less(k-1, i) {
+			data.Swap(i, hi)
+			hi++
+		}
+	}
+	return Split{lt, hi}
+}
+
+/*
+HeapSelect swaps elements in the data provided so that the first k elements are
+the smallest k elements, and returns the bounds of that block just like Select.
+Unlike Select it always uses the heap strategy: a single pass over the data
+keeps a max-heap of the indices of the smallest k elements seen so far, and
+only then are those elements swapped to the front.
+
+This runs in O(n log k) time rather than O(n), but calls Swap exactly k times,
+which can pay off for a tiny k when swapping elements is costly, such as for
+large structs. HeapSelect panics if k is outside of the range [0, data.Len()].
+*/
+func HeapSelect(data Interface, k int) (lo, hi int) {
+	length := data.Len()
+	if k < 0 || k > length {
+		panic(outOfRange(k, length))
+	} else if k > 0 {
+		new(selection).heapSelectionFinding(data, k, length)
+	}
+	return 0, k
+}
+
+// The largest k SelectSmallK keeps on the stack.
+const smallK = 8
+
+/*
+SelectSmallK works like QuickSelect, but is specialized for a tiny k, as is
+common on hot paths. For k up to 8 it runs the heap strategy with the indices
+of the smallest k elements kept in a fixed size array on the stack, so that it
+doesn't allocate at all, and calls Swap at most k times. For larger k it falls
+back on QuickSelect.
+*/
+func SelectSmallK(data Interface, k int) error {
+	length := data.Len()
+	if k < 0 || k > length {
+		return outOfRange(k, length)
+	} else if k > smallK {
+		return new(selection).quickSelectN(data, k, length)
+	} else if k == 0 {
+		return nil
+	}
+
+	var indices [smallK]int
+	heap := indices[:k]
+	for i := range heap {
+		heap[i] = i
+	}
+	heapInit(data, heap)
+	for i := k; i < length; i++ {
+		if data.Less(i, heap[0]) {
+			heap[0] = i
+			heapDown(data, heap, 0, k)
+		}
+	}
+	moveToFront(data, heap)
+	return nil
+}
+
+/*
+QuickSelectContext works like QuickSelect, but gives up and returns ctx.Err()
+once the context is done. The context is only looked at every so often (about
+every 65536 elements processed), so the check adds practically nothing to the
+running time. If the selection is abandoned the data is left partially
+reordered.
+*/
+func QuickSelectContext(ctx context.Context, data Interface, k int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return (&selection{ctx: ctx}).quickSelect(data, k)
+}
+
+/*
+SelectSafe works like QuickSelect, but recovers from a panic in the data's Len,
+Less or Swap methods and returns it as an error instead, so that a faulty
+comparator doesn't bring down the whole process. If the panic value is an error
+it's wrapped, so errors.Is and errors.As see through it.
+
+When an error is returned after a panic, the selection was cut short and the
+data is left in whatever partially permuted order it was in at the time. No
+elements are lost or duplicated, provided Swap itself didn't panic halfway.
+*/
+func SelectSafe(data Interface, k int) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = fmt.Errorf("The selection panicked: %w", e)
+			} else {
+				err = fmt.Errorf("The selection panicked: %v", r)
+			}
+		}
+	}()
+	return QuickSelect(data, k)
+}
+
+/*
+QuickSelectSeeded works like QuickSelect, but draws the random pivots from src
+instead of from the global source of randomness. Selecting over the same input
+with sources in the same state reorders the data in exactly the same way, which
+makes it possible to reproduce a problematic run.
+*/
+func QuickSelectSeeded(data Interface, k int, src rand.Source) error {
+	return (&selection{rng: rand.New(src)}).quickSelect(data, k)
+}
+
+/*
+A Selector runs selections just like QuickSelect, but holds on to the scratch
+space some of the selection strategies need between calls. High-throughput
+callers that select over many small collections can use one to avoid allocating
+on every selection. A Selector is not safe for concurrent use; the zero value
+is ready to use.
+*/
+type Selector struct {
+	s selection
+}
+
+// NewSelector returns a new Selector.
+func NewSelector() *Selector {
+	return &Selector{}
+}
+
+// Select swaps elements in the data provided so that the first k elements are
+// the smallest k elements in the data, just like QuickSelect.
+func (sel *Selector) Select(data Interface, k int) error {
+	return sel.s.quickSelect(data, k)
+}
+
+// Picks and runs the selection strategy best suited for the data and k.
+func (s *selection) quickSelect(data Interface, k int) error {
+	return s.quickSelectN(data, k, data.Len())
+}
+
+// Does the same as quickSelect, for data whose length has already been asked
+// for, so that Len is only called once.
+func (s *selection) quickSelectN(data Interface, k, length int) error {
+	if k < 0 || k > length {
+		return outOfRange(k, length)
+	} else if k == 0 || k == length {
+		return nil
+	} else if k == 1 {
+		if minimum := findMinimum(data, length); minimum != 0 {
+			data.Swap(0, minimum)
+		}
+		return nil
+	} else if isSelected(data, k, length) {
+		return nil
+	}
+
+	strategy := chooseStrategy(length, k)
+	switch s.swapCost {
+	case CheapSwap:
+		strategy = randomizedStrategy
+	case ExpensiveSwap:
+		strategy = heapStrategy
+	}
+
+	switch strategy {
+	case naiveStrategy:
+		s.naiveSelectionFinding(data, k, length)
+		return nil
+	case heapStrategy:
+		return s.heapSelectionFinding(data, k, length)
+	default:
+		return s.randomizedSelectionFinding(data, 0, length-1, k)
+	}
+}
+
+// Min returns the index of the smallest element of the data, or -1 if the data
+// is empty. It makes a single pass over the data and never swaps. If several
+// elements are the smallest, the index of the first one is returned.
+func Min(data Interface) int {
 	length := data.Len()
-	if k < 1 || k > length {
-		return fmt.Errorf("The specified index '%d' is outside of the data's range of indices [0,%d)", k, length)
+	if length == 0 {
+		return -1
 	}
+	return findMinimum(data, length)
+}
+
+// Max returns the index of the largest element of the data, or -1 if the data
+// is empty. It makes a single pass over the data and never swaps. If several
+// elements are the largest, the index of the first one is returned.
+func Max(data Interface) int {
+	length := data.Len()
+	if length == 0 {
+		return -1
+	}
+	maximum := 0
+	for i := 1; i < length; i++ {
+		if data.Less(maximum, i) {
+			maximum = i
+		}
+	}
+	return maximum
+}
+
+// Returns the index of the smallest element, in a single pass over the data.
+func findMinimum(data Interface, length int) int {
+	minimum := 0
+	for i := 1; i < length; i++ {
+		if data.Less(i, minimum) {
+			minimum = i
+		}
+	}
+	return minimum
+}
+
+/*
+Reports whether the first k elements already are the smallest k, which is
+common when selecting again after a small update. It finds the largest of the
+first k elements and checks that nothing after them is smaller, stopping at the
+first element that is, so on data that isn't selected yet this rarely costs
+much more than k comparisons.
+*/
+func isSelected(data Interface, k, length int) bool {
+	largest := 0
+	for i := 1; i < k; i++ {
+		if data.Less(largest, i) {
+			largest = i
+		}
+	}
+	for i := k; i < length; i++ {
+		if data.Less(i, largest) {
+			return false
+		}
+	}
+	return true
+}
+
+// A strategy is one of the algorithms a selection can be carried out with.
+type strategy int
 
+const (
+	naiveStrategy strategy = iota
+	heapStrategy
+	randomizedStrategy
+)
+
+/*
+Picks the strategy best suited for selecting the smallest k of length elements.
+Small inputs are handled by the naive strategy and a tiny k relative to the
+length by the heap strategy, since both have smaller constant factors than the
+randomized selection used for everything else.
+*/
+func chooseStrategy(length, k int) strategy {
 	kRatio := float64(k) / float64(length)
-	if length <= naiveSelectionLengthThreshold && k <= naiveSelectionThreshold {
-		naiveSelectionFinding(data, k)
-	} else if kRatio <= heapSelectionKRatio && k <= heapSelectionThreshold {
-		heapSelectionFinding(data, k)
-	} else {
-		randomizedSelectionFinding(data, 0, length-1, k)
+	if length <= NaiveSelectionLengthThreshold && k <= NaiveSelectionThreshold {
+		return naiveStrategy
+	} else if kRatio <= HeapSelectionKRatio && k <= HeapSelectionThreshold {
+		return heapStrategy
 	}
+	return randomizedStrategy
+}
 
+/*
+QuickSelectSorted works like QuickSelect, but additionally sorts the first k
+elements in ascending order, which is what "top 10" listings usually need. The
+sort costs O(k log k) on top of the selection. Wrapping the data in Reverse
+yields the k largest elements sorted in descending order.
+*/
+func QuickSelectSorted(data Interface, k int) error {
+	if err := QuickSelect(data, k); err != nil {
+		return err
+	}
+	sort.Sort(prefix{data, k})
 	return nil
 }
 
+/*
+Smallest selects and sorts the smallest k elements of the data, just like
+QuickSelectSorted, and returns an iterator over their indices in ascending
+order of the elements:
+
+	for i := range quickselect.Smallest(data, 10) {
+		fmt.Println(data[i])
+	}
+
+The selection runs once, when Smallest is called, so ranging over the iterator
+is cheap and allocates nothing. Smallest panics if k is outside of the range
+[0, data.Len()].
+*/
+func Smallest(data Interface, k int) iter.Seq[int] {
+	if err := QuickSelectSorted(data, k); err != nil {
+		panic(err)
+	}
+	return func(yield func(int) bool) {
+		for i := 0; i < k; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+/*
+MultiSelect swaps elements in the data provided so that, for every k in ks, the
+first k elements are the smallest k elements in the data and the element at
+index k-1 is the k-th smallest. This is useful for computing several order
+statistics (e.g. the p50, p90 and p99 of a sample) at once.
+
+Each selection only has to work within the range left between the already
+placed neighbouring order statistics, so this does much less work than calling
+QuickSelect once per k.
+
+The values in ks must be strictly increasing and in the range [1, data.Len()],
+otherwise MultiSelect will raise an error.
+*/
+func MultiSelect(data Interface, ks []int) error {
+	length := data.Len()
+	for i, k := range ks {
+		if k < 1 || k > length {
+			return rankOutOfRange(k, length)
+		}
+		if i > 0 && k <= ks[i-1] {
+			return fmt.Errorf("The specified indices must be strictly increasing, but '%d' follows '%d'", k, ks[i-1])
+		}
+	}
+
+	multiSelectionFinding(data, 0, length-1, ks)
+	return nil
+}
+
+/*
+SelectRange swaps elements in the data provided so that data[i:j] holds exactly
+the elements whose ranks in sorted order fall in [i, j), with all the smaller
+elements before index i and all the larger ones from index j on. This is handy
+for paginating through ranked results. The elements within each of the three
+parts are in no particular order.
+
+The range must satisfy 0 <= i < j <= data.Len(), otherwise SelectRange will
+raise an error.
+*/
+func SelectRange(data Interface, i, j int) error {
+	length := data.Len()
+	if i < 0 || i >= j || j > length {
+		return fmt.Errorf("The specified range [%d,%d) is outside of the data's range of indices [0,%d)", i, j, length)
+	}
+
+	s := new(selection)
+	if i > 0 {
+		s.randomizedSelectionFinding(data, 0, length-1, i)
+	}
+	if j < length {
+		s.randomizedSelectionFinding(data, i, length-1, j)
+	}
+	return nil
+}
+
+/*
+SelectRangeIndices works like Select, but confines the selection to the range
+[start, end) of the data, which is never reordered outside of it. The k
+smallest elements of the range are placed at its front, and the returned
+bounds of the block holding them, data[lo:hi], are absolute indices, so lo is
+start and hi is start+k. This suits data whose Interface relies on absolute
+positions, such as parallel payload slices sharing an index space, which
+reslicing would throw off.
+
+SelectRangeIndices panics if the range isn't within [0, data.Len()], or if k is
+outside of the range [0, end-start].
+*/
+func SelectRangeIndices(data Interface, start, end, k int) (lo, hi int) {
+	length := data.Len()
+	if start < 0 || start > end || end > length {
+		panic(fmt.Errorf("The specified range [%d,%d) is outside of the data's range of indices [0,%d)", start, end, length))
+	} else if k < 0 || k > end-start {
+		panic(outOfRange(k, end-start))
+	}
+
+	if k > 0 && k < end-start {
+		s := selection{start: start}
+		s.randomizedSelectionFinding(data, start, end-1, start+k)
+	}
+	return start, start + k
+}
+
+/*
+TrimBounds swaps elements in the data provided so that the lowerK smallest
+elements are at the front and the upperK largest elements are at the back, and
+returns the bounds of the trimmed middle, data[lo:hi], which holds everything
+else. This is what trimmed means and similar robust statistics need. Each of
+the three parts is in no particular order.
+
+The second selection only looks at what's left after the first, so the middle
+is only partitioned once. TrimBounds panics if lowerK or upperK is negative, or
+if together they exceed data.Len().
+*/
+func TrimBounds(data Interface, lowerK, upperK int) (lo, hi int) {
+	length := data.Len()
+	if lowerK < 0 || upperK < 0 || lowerK+upperK > length {
+		panic(fmt.Errorf("The specified trim of %d and %d elements is outside of the data's length %d", lowerK, upperK, length))
+	}
+
+	lo, hi = lowerK, length-upperK
+	s := new(selection)
+	if lo > 0 && lo < length {
+		s.randomizedSelectionFinding(data, 0, length-1, lo)
+	}
+	if hi > lo && hi < length {
+		s.randomizedSelectionFinding(data, lo, length-1, hi)
+	}
+	return lo, hi
+}
+
+/*
+Places the order statistics ks within the range [low, high], by selecting the
+middle one first and then recursing on the ranges to either side of it. Only the
+side with fewer order statistics is recursed on while the other one is looped
+on, so the stack never grows deeper than log2(len(ks)) frames.
+*/
+func multiSelectionFinding(data Interface, low, high int, ks []int) {
+	for len(ks) > 0 {
+		mid := len(ks) / 2
+		k := ks[mid]
+		new(selection).randomizedSelectionFinding(data, low, high, k-1)
+		multiSelectionFinding(data, k, high, ks[mid+1:])
+		high, ks = k-2, ks[:mid]
+	}
+}
+
 // IntQuickSelect mutates the data so that the first k elements in the int
 // slice are the k smallest elements in the slice. This is a convenience
 // method for QuickSelect on int slices.
 func IntQuickSelect(data []int, k int) error {
-	return QuickSelect(IntSlice(data), k)
+	return orderedQuickSelect(data, k)
+}
+
+// IntSmallestK returns a newly allocated slice holding the k smallest elements
+// of the int slice, leaving the data itself untouched. The returned elements
+// are in no particular order.
+func IntSmallestK(data []int, k int) ([]int, error) {
+	scratch := append([]int(nil), data...)
+	if err := orderedQuickSelect(scratch, k); err != nil {
+		return nil, err
+	}
+	return append([]int(nil), scratch[:k]...), nil
+}
+
+// IntSmallestKSorted works like IntSmallestK, but returns the k smallest
+// elements sorted in ascending order. It runs in O(n + k log k) time, which beats
+// sorting all of the data when k is small.
+func IntSmallestKSorted(data []int, k int) ([]int, error) {
+	smallestK, err := IntSmallestK(data, k)
+	if err != nil {
+		return nil, err
+	}
+	sort.Ints(smallestK)
+	return smallestK, nil
+}
+
+// IntTopKSortedDesc returns a newly allocated slice holding the k largest
+// elements of the int slice, sorted in descending order, and leaves the data
+// itself untouched. Duplicates are kept, so a value tied across the k-th place
+// appears as many times as fits. It runs in O(n + k log k) time.
+func IntTopKSortedDesc(data []int, k int) ([]int, error) {
+	scratch := append([]int(nil), data...)
+	if err := orderedSelect(scratch, k, true); err != nil {
+		return nil, err
+	}
+	largestK := append([]int(nil), scratch[:k]...)
+	sort.Sort(sort.Reverse(sort.IntSlice(largestK)))
+	return largestK, nil
+}
+
+/*
+IntSelectInto writes the k smallest elements of src into dst[:k], reusing the
+capacity of dst, and returns dst[:k]. The elements are in no particular order
+and src is left untouched. Nothing is allocated, which suits callers that pool
+their output buffers. The smallest elements are kept in a max-heap in dst as
+src is scanned, so this runs in O(n log k) time.
+
+An error is raised if k is outside of the range [0, len(src)] or if
+cap(dst) < k.
+*/
+func IntSelectInto(dst, src []int, k int) ([]int, error) {
+	if k < 0 || k > len(src) {
+		return nil, outOfRange(k, len(src))
+	} else if cap(dst) < k {
+		return nil, fmt.Errorf("The destination's capacity %d is too small for %d elements", cap(dst), k)
+	} else if k == 0 {
+		return dst[:0], nil
+	}
+
+	heap := append(dst[:0], src[:k]...)
+	for i := k/2 - 1; i >= 0; i-- {
+		orderedSiftDown(heap, i, k)
+	}
+	for _, elem := range src[k:] {
+		if elem < heap[0] {
+			heap[0] = elem
+			orderedSiftDown(heap, 0, k)
+		}
+	}
+	return heap, nil
 }
 
 // Float64Select mutates the data so that the first k elements in the float64
 // slice are the k smallest elements in the slice. This is a convenience
 // method for QuickSelect on float64 slices.
 func Float64QuickSelect(data []float64, k int) error {
-	return QuickSelect(Float64Slice(data), k)
+	return orderedQuickSelect(data, k)
+}
+
+// Float32QuickSelect mutates the data so that the first k elements in the
+// float32 slice are the k smallest elements in the slice. This is a convenience
+// method for QuickSelect on float32 slices, which spares widening them to
+// float64.
+func Float32QuickSelect(data []float32, k int) error {
+	return orderedQuickSelect(data, k)
 }
 
 // StringQuickSelect mutates the data so that the first k elements in the string
 // slice are the k smallest elements in the slice. This is a convenience
 // method for QuickSelect on string slices.
 func StringQuickSelect(data []string, k int) error {
-	return QuickSelect(StringSlice(data), k)
+	return orderedQuickSelect(data, k)
+}
+
+// Int32QuickSelect mutates the data so that the first k elements in the int32
+// slice are the k smallest elements in the slice. This is a convenience
+// method for QuickSelect on int32 slices.
+func Int32QuickSelect(data []int32, k int) error {
+	return orderedQuickSelect(data, k)
+}
+
+// Int64QuickSelect mutates the data so that the first k elements in the int64
+// slice are the k smallest elements in the slice. This is a convenience
+// method for QuickSelect on int64 slices.
+func Int64QuickSelect(data []int64, k int) error {
+	return orderedQuickSelect(data, k)
+}
+
+// UintQuickSelect mutates the data so that the first k elements in the uint
+// slice are the k smallest elements in the slice. This is a convenience
+// method for QuickSelect on uint slices.
+func UintQuickSelect(data []uint, k int) error {
+	return orderedQuickSelect(data, k)
+}
+
+// Uint64QuickSelect mutates the data so that the first k elements in the uint64
+// slice are the k smallest elements in the slice. This is a convenience
+// method for QuickSelect on uint64 slices.
+func Uint64QuickSelect(data []uint64, k int) error {
+	return orderedQuickSelect(data, k)
+}
+
+// ByteQuickSelect mutates the data so that the first k elements in the byte
+// slice are the k smallest elements in the slice. This is a convenience
+// method for QuickSelect on byte slices.
+func ByteQuickSelect(data []byte, k int) error {
+	return orderedQuickSelect(data, k)
+}
+
+// TimeQuickSelect mutates the data so that the first k elements in the
+// time.Time slice are the k earliest times in the slice. This is a convenience
+// method for QuickSelect on time.Time slices.
+func TimeQuickSelect(data []time.Time, k int) error {
+	return QuickSelect(TimeSlice(data), k)
+}
+
+// IntQuickSelectLargest mutates the data so that the first k elements in the
+// int slice are the k largest elements in the slice. The k largest elements
+// are not sorted among themselves, in either direction. It selects the same
+// elements as QuickSelect on Reverse(IntSlice(data)), but compares the ints
+// directly; use QuickSelectSorted on the latter if the block should be in
+// descending order.
+func IntQuickSelectLargest(data []int, k int) error {
+	return orderedSelect(data, k, true)
+}
+
+// Float64QuickSelectLargest mutates the data so that the first k elements in
+// the float64 slice are the k largest elements in the slice. The k largest
+// elements are not sorted among themselves. NaNs are considered smaller than
+// any other value, so they are only selected once every other value has been.
+// Like QuickSelectLargestOrdered, it compares the float64s directly.
+func Float64QuickSelectLargest(data []float64, k int) error {
+	return orderedSelect(data, k, true)
+}
+
+// StringQuickSelectLargest mutates the data so that the first k elements in
+// the string slice are the k largest elements in the slice. The k largest
+// elements are not sorted among themselves. Like QuickSelectLargestOrdered, it
+// compares the strings directly.
+func StringQuickSelectLargest(data []string, k int) error {
+	return orderedSelect(data, k, true)
+}
+
+// TimeQuickSelectLatest mutates the data so that the first k elements in the
+// time.Time slice are the k latest times in the slice. The k latest times are
+// not sorted among themselves.
+func TimeQuickSelectLatest(data []time.Time, k int) error {
+	return QuickSelect(Reverse(TimeSlice(data)), k)
+}
+
+/*
+Moves the largest of the first k elements to index k-1. After a selection this
+is the k-th smallest element of the whole collection.
+*/
+func placeKth(data Interface, k int) {
+	largest := 0
+	for i := 1; i < k; i++ {
+		if data.Less(largest, i) {
+			largest = i
+		}
+	}
+	data.Swap(largest, k-1)
+}
+
+// IntSelectKth returns the k-th smallest element of the int slice. Like
+// IntQuickSelect it leaves the k smallest elements in the first k positions,
+// and additionally places the returned element at index k-1. k == 1 yields the
+// minimum and k == len(data) yields the maximum.
+func IntSelectKth(data []int, k int) (int, error) {
+	if k < 1 || k > len(data) {
+		return 0, rankOutOfRange(k, len(data))
+	}
+	orderedQuickSelect(data, k)
+	placeKth(IntSlice(data), k)
+	return data[k-1], nil
+}
+
+// Float64SelectKth returns the k-th smallest element of the float64 slice. Like
+// Float64QuickSelect it leaves the k smallest elements in the first k
+// positions, and additionally places the returned element at index k-1. k == 1
+// yields the minimum and k == len(data) yields the maximum.
+func Float64SelectKth(data []float64, k int) (float64, error) {
+	if k < 1 || k > len(data) {
+		return 0, rankOutOfRange(k, len(data))
+	}
+	orderedQuickSelect(data, k)
+	placeKth(Float64Slice(data), k)
+	return data[k-1], nil
+}
+
+// StringSelectKth returns the k-th smallest element of the string slice. Like
+// StringQuickSelect it leaves the k smallest elements in the first k
+// positions, and additionally places the returned element at index k-1. k == 1
+// yields the minimum and k == len(data) yields the maximum.
+func StringSelectKth(data []string, k int) (string, error) {
+	if k < 1 || k > len(data) {
+		return "", rankOutOfRange(k, len(data))
+	}
+	orderedQuickSelect(data, k)
+	placeKth(StringSlice(data), k)
+	return data[k-1], nil
+}
+
+/*
+QuickSelectFunc swaps elements of a collection of length n so that the first k
+elements are the smallest k elements, using the provided less and swap
+functions. It mirrors sort.Slice and is handy for ad-hoc selections where
+defining a type that implements Interface would be tedious:
+
+	orders := []Order{...}
+	quickselect.QuickSelectFunc(len(orders), 10,
+		func(i, j int) bool { return orders[i].Price < orders[j].Price },
+		func(i, j int) { orders[i], orders[j] = orders[j], orders[i] })
+*/
+func QuickSelectFunc(n, k int, less func(i, j int) bool, swap func(i, j int)) error {
+	return QuickSelect(lessSwap{n, less, swap}, k)
+}
+
+/*
+SelectPaired swaps elements of the keys so that the first k are the smallest k
+keys, just like QuickSelect, and calls swapAlso with every pair of indices it
+swaps. This keeps data that's stored alongside the keys, such as a parallel
+slice of payloads, aligned with them:
+
+	names := []string{...}
+	quickselect.SelectPaired(quickselect.IntSlice(ages), 10, func(i, j int) {
+		names[i], names[j] = names[j], names[i]
+	})
+*/
+func SelectPaired(keys Interface, k int, swapAlso func(i, j int)) error {
+	return QuickSelect(lessSwap{keys.Len(), keys.Less, func(i, j int) {
+		keys.Swap(i, j)
+		swapAlso(i, j)
+	}}, k)
+}
+
+// IndexedFloat64 is a value along with the index it had in the data it was
+// selected from.
+type IndexedFloat64 struct {
+	Index int
+	Value float64
+}
+
+/*
+SelectTopKWithIndices swaps elements in the data provided so that the first k
+elements are the smallest k elements, just like QuickSelect, and returns them
+along with the indices they had before the data was reordered, which can be
+used to join them back to the rows they came from. The i-th returned element is
+the one now at data[i].
+
+SelectTopKWithIndices panics if k is outside of the range [0, data.Len()].
+*/
+func SelectTopKWithIndices(data Float64Slice, k int) []IndexedFloat64 {
+	indices := make([]int, len(data))
+	for i := range indices {
+		indices[i] = i
+	}
+	if err := SelectPaired(data, k, func(i, j int) {
+		indices[i], indices[j] = indices[j], indices[i]
+	}); err != nil {
+		panic(err)
+	}
+
+	selected := make([]IndexedFloat64, k)
+	for i := range selected {
+		selected[i] = IndexedFloat64{indices[i], data[i]}
+	}
+	return selected
+}
+
+/*
+SelectMulti swaps elements in the data provided so that the first k elements
+are the smallest k elements, with ties under the data's own Less broken by
+lessSecondary, e.g. the cheapest products and, among equally cheap ones, those
+that come first alphabetically. The block thus reflects the combined ordering,
+and which of the tied elements make it in no longer depends on the pivots
+picked, as long as lessSecondary itself breaks all remaining ties.
+*/
+func SelectMulti(data Interface, k int, lessSecondary func(i, j int) bool) error {
+	return QuickSelect(lessSwap{data.Len(), func(i, j int) bool {
+		return data.Less(i, j) || !data.Less(j, i) && lessSecondary(i, j)
+	}, data.Swap}, k)
+}
+
+// Float64StringSelect mutates both slices so that the first k keys are the k
+// smallest keys, and the first k payloads are the ones that were paired with
+// them. The slices must be of the same length. NaN keys are treated as smaller
+// than any other key.
+func Float64StringSelect(keys []float64, payload []string, k int) error {
+	if len(keys) != len(payload) {
+		return fmt.Errorf("The keys and payload must be of the same length, but got %d and %d", len(keys), len(payload))
+	}
+	return SelectPaired(Float64Slice(keys), k, func(i, j int) {
+		payload[i], payload[j] = payload[j], payload[i]
+	})
 }
@@ -1,8 +1,16 @@
 package quickselect
 
 import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"runtime/debug"
 	"sort"
 	"testing"
+	"time"
 )
 
 type TestData struct {
@@ -52,8 +60,8 @@ func TestQuickSelectWithRepeatedElements(t *testing.T) {
 func TestQuickSelectEmptyDataStructure(t *testing.T) {
 	fixture := TestData{[]int{}}
 	err := QuickSelect(fixture, 0)
-	if err == nil {
-		t.Errorf("Should have raised error on index outside of array length.")
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
 	}
 
 	err = QuickSelect(fixture, 5)
@@ -67,6 +75,37 @@ func TestQuickSelectEmptyDataStructure(t *testing.T) {
 	}
 }
 
+func TestQuickSelectZero(t *testing.T) {
+	array := []int{50, 20, 30, 25, 45, 2, 6, 10, 3, 4, 5}
+	data := &swapCounter{Interface: IntSlice(append([]int(nil), array...))}
+
+	if err := QuickSelect(data, 0); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if data.swaps != 0 {
+		t.Errorf("Expected no swaps for k = 0, but got %d", data.swaps)
+	}
+	if lo, hi := Select(data, 0); lo != 0 || hi != 0 {
+		t.Errorf("Expected bounds to be [0, 0), but got [%d, %d)", lo, hi)
+	}
+	if err := IntQuickSelect(array, 0); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if smallestK, err := IntSmallestK(array, 0); err != nil || smallestK != nil {
+		t.Errorf("Expected a nil slice and no error, but got '%v' and '%v'", smallestK, err)
+	}
+	if indices := SelectIndices(len(array), 0, IntSlice(array).Less); indices != nil {
+		t.Errorf("Expected a nil slice, but got '%v'", indices)
+	}
+
+	if err := QuickSelect(data, -1); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+	if _, err := IntSelectKth(array, 0); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
 func TestIntSliceQuickSelect(t *testing.T) {
 	fixtures := []struct {
 		Array     IntSlice
@@ -99,6 +138,10 @@ func TestResetCurrentLargest(t *testing.T) {
 		{[]int{0, 0, 5, 3, 5, 2}, 2},
 		{[]int{3}, 0},
 		{[]int{35, 25, 15, 10, 5}, 0},
+		{[]int{7, 7, 7, 7}, 0},
+		{[]int{5, 4, 3, 2, 1}, 0},
+		{[]int{1, 2, 3, 4, 5}, 4},
+		{[]int{4, 9, 9, 1}, 1},
 	}
 
 	for _, fixture := range fixtures {
@@ -111,7 +154,25 @@ func TestResetCurrentLargest(t *testing.T) {
 		if lastIndex != fixture.ExpectedLast {
 			t.Errorf("Expected last index of '%d', but got '%d' instead", fixture.ExpectedLast, lastIndex)
 		}
+		for _, i := range indices {
+			if fixture.Array[i] > fixture.Array[lastIndex] {
+				t.Errorf("Expected index '%d' to point at the largest of '%v'", lastIndex, fixture.Array)
+				break
+			}
+		}
+		if !hasSameElements(indices, rangeOfInts(len(fixture.Array))) {
+			t.Errorf("Expected indices to stay a permutation, but got '%v'", indices)
+		}
+	}
+}
+
+// Returns the ints in [0, n).
+func rangeOfInts(n int) []int {
+	ints := make([]int, n)
+	for i := range ints {
+		ints[i] = i
 	}
+	return ints
 }
 
 func TestNaiveSelectionFinding(t *testing.T) {
@@ -127,7 +188,7 @@ func TestNaiveSelectionFinding(t *testing.T) {
 	}
 
 	for _, fixture := range fixtures {
-		naiveSelectionFinding(fixture.Array, 4)
+		new(selection).naiveSelectionFinding(fixture.Array, 4, len(fixture.Array))
 
 		resultK := fixture.Array[:4]
 		if !hasSameElements(resultK, fixture.ExpectedK) {
@@ -136,6 +197,33 @@ func TestNaiveSelectionFinding(t *testing.T) {
 	}
 }
 
+func TestNaiveSelectionFindingSmallInputs(t *testing.T) {
+	orderings := map[string]func(i, n int) int{
+		"all equal":      func(i, n int) int { return 3 },
+		"reverse sorted": func(i, n int) int { return n - i },
+		"sorted":         func(i, n int) int { return i },
+		"two values":     func(i, n int) int { return (n - i) % 2 },
+	}
+
+	for name, ordering := range orderings {
+		for n := 1; n < NaiveSelectionLengthThreshold; n += 7 {
+			for k := 1; k <= n; k++ {
+				array := make(IntSlice, n)
+				for i := range array {
+					array[i] = ordering(i, n)
+				}
+				sorted := append([]int(nil), array...)
+				sort.Ints(sorted)
+
+				new(selection).naiveSelectionFinding(array, k, len(array))
+				if !hasSameElements(array[:k], sorted[:k]) || !hasSameElements(array, sorted) {
+					t.Errorf("Expected naive selection to find the smallest %d of %d %s elements, but got '%v'", k, n, name, array[:k])
+				}
+			}
+		}
+	}
+}
+
 func TestHeapSelectionFinding(t *testing.T) {
 	fixtures := []struct {
 		Array     IntSlice
@@ -149,7 +237,7 @@ func TestHeapSelectionFinding(t *testing.T) {
 	}
 
 	for _, fixture := range fixtures {
-		heapSelectionFinding(fixture.Array, 4)
+		new(selection).heapSelectionFinding(fixture.Array, 4, len(fixture.Array))
 
 		resultK := fixture.Array[:4]
 		if !hasSameElements(resultK, fixture.ExpectedK) {
@@ -158,6 +246,306 @@ func TestHeapSelectionFinding(t *testing.T) {
 	}
 }
 
+// panickyLess panics once Less has been called calls times.
+type panickyLess struct {
+	IntSlice
+	calls int
+	value interface{}
+}
+
+func (p *panickyLess) Less(i, j int) bool {
+	if p.calls--; p.calls < 0 {
+		panic(p.value)
+	}
+	return p.IntSlice.Less(i, j)
+}
+
+func TestSelectSafe(t *testing.T) {
+	array := make(IntSlice, 1000)
+	for i := range array {
+		array[i] = rand.IntN(100)
+	}
+	sorted := append([]int(nil), array...)
+	sort.Ints(sorted)
+
+	cause := errors.New("nil key")
+	for _, value := range []interface{}{"boom", cause} {
+		data := &panickyLess{IntSlice: array, calls: 500, value: value}
+		err := SelectSafe(data, 300)
+		if err == nil {
+			t.Errorf("Should have raised error on a panicking Less.")
+		} else if value == cause && !errors.Is(err, cause) {
+			t.Errorf("Expected error to wrap '%s', but got '%s'", cause, err)
+		}
+
+		remaining := append([]int(nil), array...)
+		sort.Ints(remaining)
+		if !hasSameElements(remaining, sorted) {
+			t.Errorf("Expected the data to be a permutation of the original")
+		}
+	}
+
+	if err := SelectSafe(array, 300); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElements(array[:300], sorted[:300]) {
+		t.Errorf("Wrong smallest 300 elements")
+	}
+	if err := SelectSafe(array, 1001); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestSmallest(t *testing.T) {
+	data := IntSlice{16, 29, -11, 25, 28, -14, 10, 4, 7, -27}
+	expected := []int{-27, -14, -11, 4}
+
+	var smallest []int
+	for i := range Smallest(data, 4) {
+		smallest = append(smallest, data[i])
+	}
+	if len(smallest) != len(expected) {
+		t.Fatalf("Expected sorted smallest K elements to be '%v', but got '%v'", expected, smallest)
+	}
+	for i := range expected {
+		if smallest[i] != expected[i] {
+			t.Errorf("Expected sorted smallest K elements to be '%v', but got '%v'", expected, smallest)
+			break
+		}
+	}
+
+	for i := range Smallest(data, 4) {
+		if i > 0 {
+			t.Errorf("Expected iteration to stop after break, but got index %d", i)
+		}
+		break
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Should have panicked on index outside of array length.")
+		}
+	}()
+	Smallest(data, 11)
+}
+
+func TestTrimBounds(t *testing.T) {
+	array := make([]int, 1000)
+	for i := range array {
+		array[i] = rand.IntN(300)
+	}
+	sorted := append([]int(nil), array...)
+	sort.Ints(sorted)
+
+	fixtures := []struct{ LowerK, UpperK int }{
+		{0, 0}, {10, 10}, {0, 50}, {50, 0}, {1, 999}, {999, 1}, {500, 500}, {0, 1000}, {1000, 0},
+	}
+	for _, fixture := range fixtures {
+		data := append(IntSlice(nil), array...)
+		lo, hi := TrimBounds(data, fixture.LowerK, fixture.UpperK)
+		if lo != fixture.LowerK || hi != len(data)-fixture.UpperK {
+			t.Errorf("Expected bounds [%d, %d), but got [%d, %d)", fixture.LowerK, len(data)-fixture.UpperK, lo, hi)
+			continue
+		}
+		if !hasSameElements(data[:lo], sorted[:lo]) || !hasSameElements(data[lo:hi], sorted[lo:hi]) || !hasSameElements(data[hi:], sorted[hi:]) {
+			t.Errorf("Wrong trim of %d and %d elements", fixture.LowerK, fixture.UpperK)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Should have panicked on a trim exceeding the data.")
+		}
+	}()
+	TrimBounds(IntSlice(array), 600, 401)
+}
+
+func TestSelectSplit(t *testing.T) {
+	array := make([]int, 1000)
+	for i := range array {
+		array[i] = rand.IntN(50)
+	}
+	sorted := append([]int(nil), array...)
+	sort.Ints(sorted)
+
+	for _, k := range []int{1, 2, 100, 500, 999, 1000} {
+		data := append(IntSlice(nil), array...)
+		split := SelectSplit(data, k)
+
+		kth := sorted[k-1]
+		if split.Lo > k || split.Hi < k {
+			t.Errorf("Expected split [%d, %d) to contain the boundary %d", split.Lo, split.Hi, k)
+		}
+		for i, elem := range data {
+			if i < split.Lo && elem >= kth || i >= split.Lo && i < split.Hi && elem != kth || i >= split.Hi && elem <= kth {
+				t.Errorf("Element %d at index %d is on the wrong side of split [%d, %d) around %d", elem, i, split.Lo, split.Hi, kth)
+				break
+			}
+		}
+		if !hasSameElements(data[:k], sorted[:k]) {
+			t.Errorf("Wrong smallest %d elements", k)
+		}
+	}
+
+	if split := SelectSplit(IntSlice{3, 1, 2}, 0); split != (Split{}) {
+		t.Errorf("Expected an empty split, but got %+v", split)
+	}
+}
+
+// Returns a description of what's wrong with the block data[lo:hi] as the
+// selection of the k smallest of the original elements, or "" if nothing is.
+func checkBlock(data IntSlice, original []int, k, lo, hi int) string {
+	if lo != 0 || hi != k {
+		return fmt.Sprintf("expected block bounds [0,%d), but got [%d,%d)", k, lo, hi)
+	}
+	sorted := append([]int(nil), original...)
+	sort.Ints(sorted)
+	if !hasSameElements(data, sorted) {
+		return "expected the data to be a permutation of the original"
+	}
+	if !hasSameElements(data[:k], sorted[:k]) {
+		return fmt.Sprintf("expected the block to hold the smallest %d elements, but got '%v'", k, data[:k])
+	}
+	return ""
+}
+
+func TestSelectDuplicateRuns(t *testing.T) {
+	// The k-th and (k+1)-th smallest elements are equal, within runs of
+	// duplicates far longer than k.
+	run := func(value, n int) []int {
+		ints := make([]int, n)
+		for i := range ints {
+			ints[i] = value
+		}
+		return ints
+	}
+	fixtures := []struct {
+		Array []int
+		Ks    []int
+	}{
+		{run(5, 1000), []int{1, 2, 7, 500, 999}},
+		{append(run(5, 1000), 1, 2, 9), []int{1, 2, 3, 4, 10, 1001, 1002}},
+		{append(append(run(3, 20), run(1, 3)...), run(7, 2000)...), []int{2, 3, 4, 22, 23, 24, 100, 2022}},
+		{append(run(0, 5), run(-1, 5000)...), []int{1, 9, 4999, 5000, 5001, 5004}},
+	}
+
+	for _, fixture := range fixtures {
+		for _, k := range fixture.Ks {
+			for name, selection := range map[string]func(data IntSlice) (lo, hi int){
+				"Select":     func(data IntSlice) (lo, hi int) { return Select(data, k) },
+				"HeapSelect": func(data IntSlice) (lo, hi int) { return HeapSelect(data, k) },
+				"SelectThreshold": func(data IntSlice) (lo, hi int) {
+					lo, hi, _ = SelectThreshold(data, k)
+					return lo, hi
+				},
+				"SelectCmp": func(data IntSlice) (lo, hi int) {
+					SelectCmp(len(data), k, func(i, j int) int { return cmp.Compare(data[i], data[j]) }, data.Swap)
+					return 0, k
+				},
+				"QuickSelectDeterministic": func(data IntSlice) (lo, hi int) {
+					QuickSelectDeterministic(data, k)
+					return 0, k
+				},
+			} {
+				data := append(IntSlice(nil), fixture.Array...)
+				lo, hi := selection(data)
+				if problem := checkBlock(data, fixture.Array, k, lo, hi); problem != "" {
+					t.Errorf("%s for k = %d of %d elements: %s", name, k, len(data), problem)
+				}
+			}
+		}
+	}
+}
+
+func FuzzSelect(f *testing.F) {
+	f.Add([]byte{5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 1, 9}, 3)
+	f.Add([]byte{0, 0, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, 4)
+	f.Add([]byte{2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1}, 6)
+	f.Add([]byte{}, 0)
+
+	f.Fuzz(func(t *testing.T, input []byte, k int) {
+		// Few distinct values make for long runs of duplicates.
+		original := make([]int, len(input))
+		for i, b := range input {
+			original[i] = int(b % 4)
+		}
+		if k < 0 {
+			k = -k
+		}
+		k %= len(original) + 1
+
+		data := append(IntSlice(nil), original...)
+		lo, hi := Select(data, k)
+		if problem := checkBlock(data, original, k, lo, hi); problem != "" {
+			t.Errorf("Select for k = %d of '%v': %s", k, original, problem)
+		}
+
+		data = append(IntSlice(nil), original...)
+		split := SelectSplit(data, k)
+		if problem := checkBlock(data, original, k, 0, k); problem != "" || split.Lo > k || split.Hi < k {
+			t.Errorf("SelectSplit for k = %d of '%v' gave %+v: %s", k, original, split, problem)
+		}
+	})
+}
+
+func TestSelectSmallK(t *testing.T) {
+	array := make([]int, 10000)
+	for i := range array {
+		array[i] = rand.IntN(1000)
+	}
+	sorted := append([]int(nil), array...)
+	sort.Ints(sorted)
+
+	for _, k := range []int{0, 1, 3, smallK, smallK + 1, 100} {
+		ints := append(IntSlice(nil), array...)
+		if err := SelectSmallK(ints, k); err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if !hasSameElements(ints[:k], sorted[:k]) {
+			t.Errorf("Expected smallest K elements to be '%v', but got '%v'", sorted[:k], ints[:k])
+		}
+	}
+
+	var data Interface = IntSlice(append([]int(nil), array...))
+	allocs := testing.AllocsPerRun(100, func() {
+		SelectSmallK(data, smallK)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, but got %g", allocs)
+	}
+
+	if err := SelectSmallK(IntSlice{1, 2}, 3); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestHeapSelect(t *testing.T) {
+	array := []int{16, 29, -11, 25, 28, -14, 10, 4, 7, -27}
+	for k := 0; k <= len(array); k++ {
+		sorted := append([]int(nil), array...)
+		sort.Ints(sorted)
+
+		data := &swapCounter{Interface: IntSlice(append([]int(nil), array...))}
+		lo, hi := HeapSelect(data, k)
+		if lo != 0 || hi != k {
+			t.Errorf("Expected bounds to be [0, %d), but got [%d, %d)", k, lo, hi)
+		}
+		if resultK := data.Interface.(IntSlice)[:k]; !hasSameElements(resultK, sorted[:k]) {
+			t.Errorf("Expected smallest K elements to be '%v', but got '%v'", sorted[:k], resultK)
+		}
+		if data.swaps > k {
+			t.Errorf("Expected at most %d swaps, but got %d", k, data.swaps)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Should have panicked on index outside of array length.")
+		}
+	}()
+	HeapSelect(IntSlice(array), len(array)+1)
+}
+
 func TestFloat64SliceQuickSelect(t *testing.T) {
 	fixtures := []struct {
 		Array     Float64Slice
@@ -181,106 +569,1574 @@ func TestFloat64SliceQuickSelect(t *testing.T) {
 	}
 }
 
-func hasSameElements(array1, array2 []int) bool {
-	elements := make(map[int]int)
+func TestFloat32SliceQuickSelect(t *testing.T) {
+	nan := float32(math.NaN())
+	fixtures := []struct {
+		Array     Float32Slice
+		ExpectedK []float32
+	}{
+		{[]float32{0.0, 14.3, 16.5, 29.7, 12.6, 2.4, 4.9, 4.2, 7.1, 29.3}, []float32{0.0, 2.4, 4.2, 4.9}},
+		{[]float32{9.3, 3.3, 2.7, 18.5}, []float32{9.3, 3.3, 2.7, 18.5}},
+		{[]float32{16.1, 29.3, -11.5, 25.3, 28.8, -14.7, 10.5, 4.4, 7.5, -27.9}, []float32{-27.9, -11.5, -14.7, 4.4}},
+	}
 
-	for _, elem1 := range array1 {
-		elements[elem1]++
+	for _, fixture := range fixtures {
+		err := fixture.Array.QuickSelect(4)
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+
+		resultK := fixture.Array[:4]
+		if !hasSameElementsOrdered(resultK, fixture.ExpectedK) {
+			t.Errorf("Expected smallest K elements to be '%v', but got '%v'", fixture.ExpectedK, resultK)
+		}
 	}
 
-	for _, elem2 := range array2 {
-		elements[elem2]--
+	// NaNs must be ordered just like Float64Slice orders them.
+	float32s := Float32Slice{3, nan, -2, 7, nan, 1}
+	float64s := Float64Slice{3, math.NaN(), -2, 7, math.NaN(), 1}
+	for i := range float32s {
+		for j := range float32s {
+			if float32s.Less(i, j) != float64s.Less(i, j) {
+				t.Errorf("Expected Less(%g, %g) to agree with Float64Slice", float32s[i], float32s[j])
+			}
+		}
 	}
 
-	for _, count := range elements {
-		if count != 0 {
-			return false
+	if err := Float32QuickSelect(float32s, 3); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	for _, f := range float32s[:3] {
+		if f != -2 && !math.IsNaN(float64(f)) {
+			t.Errorf("Expected smallest K elements to be NaN, NaN and -2, but got '%v'", float32s[:3])
 		}
 	}
-	return true
+
+	if err := Float32QuickSelect(float32s, 7); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
 }
 
-func hasSameElementsFloat64(array1, array2 []float64) bool {
-	elements := make(map[float64]int)
+func TestQuickSelectFunc(t *testing.T) {
+	type order struct {
+		ID    int
+		Price float64
+	}
+	orders := []order{{1, 30.5}, {2, 12.25}, {3, 99}, {4, 4.75}, {5, 18}, {6, 12.5}}
 
-	for _, elem1 := range array1 {
-		elements[elem1]++
+	err := QuickSelectFunc(len(orders), 3,
+		func(i, j int) bool { return orders[i].Price < orders[j].Price },
+		func(i, j int) { orders[i], orders[j] = orders[j], orders[i] })
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
 	}
 
-	for _, elem2 := range array2 {
-		elements[elem2]--
+	ids := make([]int, 3)
+	for i, o := range orders[:3] {
+		ids[i] = o.ID
+	}
+	expectedIDs := []int{2, 4, 6}
+	if !hasSameElements(ids, expectedIDs) {
+		t.Errorf("Expected smallest K orders to be '%v', but got '%v'", expectedIDs, ids)
 	}
 
-	for _, count := range elements {
-		if count != 0 {
-			return false
-		}
+	err = QuickSelectFunc(len(orders), -1,
+		func(i, j int) bool { return orders[i].Price < orders[j].Price },
+		func(i, j int) { orders[i], orders[j] = orders[j], orders[i] })
+	if err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
 	}
-	return true
 }
 
-func bench(b *testing.B, size, k int, quickselect bool) {
-	b.StopTimer()
-	data := make(IntSlice, size)
-	x := ^uint32(0)
-	for i := 0; i < b.N; i++ {
-		for n := size - 3; n <= size+3; n++ {
-			for i := 0; i < len(data); i++ {
-				x += x
-				x ^= 1
-				if int32(x) < 0 {
-					x ^= 0x88888eef
-				}
-				data[i] = int(x % uint32(n/5))
-			}
-			if quickselect {
-				b.StartTimer()
-				QuickSelect(data, k)
-				b.StopTimer()
-			} else {
-				b.StartTimer()
-				sort.Sort(data)
-				b.StopTimer()
-			}
+func TestFloat64StringSelect(t *testing.T) {
+	keys := []float64{3.5, -1, 12, 0.25, 7, 2}
+	payload := []string{"c", "a", "f", "b", "e", "d"}
+	pairs := make(map[float64]string)
+	for i := range keys {
+		pairs[keys[i]] = payload[i]
+	}
+
+	if err := Float64StringSelect(keys, payload, 3); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElementsFloat64(keys[:3], []float64{-1, 0.25, 2}) {
+		t.Errorf("Expected smallest K keys to be '%v', but got '%v'", []float64{-1, 0.25, 2}, keys[:3])
+	}
+	for i := range keys {
+		if pairs[keys[i]] != payload[i] {
+			t.Errorf("Expected key %g to be paired with '%s', but got '%s'", keys[i], pairs[keys[i]], payload[i])
 		}
 	}
+
+	if err := Float64StringSelect(keys, payload[1:], 3); err == nil {
+		t.Errorf("Should have raised error on slices of different lengths.")
+	}
+	if err := Float64StringSelect(keys, payload, 7); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
 }
 
-// Benchmarks for QuickSelect
-func BenchmarkQuickSelectSize1e2K1e1(b *testing.B) { bench(b, 1e2, 1e1, true) }
+type product struct {
+	Name  string
+	Price float64
+}
 
-func BenchmarkQuickSelectSize1e3K1e1(b *testing.B) { bench(b, 1e3, 1e1, true) }
-func BenchmarkQuickSelectSize1e3K1e2(b *testing.B) { bench(b, 1e3, 1e2, true) }
+type byPrice []product
 
-func BenchmarkQuickSelectSize1e4K1e1(b *testing.B) { bench(b, 1e4, 1e1, true) }
-func BenchmarkQuickSelectSize1e4K1e2(b *testing.B) { bench(b, 1e4, 1e2, true) }
-func BenchmarkQuickSelectSize1e4K1e3(b *testing.B) { bench(b, 1e4, 1e3, true) }
+func (t byPrice) Len() int           { return len(t) }
+func (t byPrice) Less(i, j int) bool { return t[i].Price < t[j].Price }
+func (t byPrice) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
 
-func BenchmarkQuickSelectSize1e5K1e1(b *testing.B) { bench(b, 1e5, 1e1, true) }
-func BenchmarkQuickSelectSize1e5K1e2(b *testing.B) { bench(b, 1e5, 1e2, true) }
-func BenchmarkQuickSelectSize1e5K1e3(b *testing.B) { bench(b, 1e5, 1e3, true) }
-func BenchmarkQuickSelectSize1e5K1e4(b *testing.B) { bench(b, 1e5, 1e4, true) }
+func TestSelectTopKWithIndices(t *testing.T) {
+	data := Float64Slice{16.1, 29.3, -11.5, 25.3, 28.8, -14.7, 10.5, 4.4, 7.5, -27.9}
+	original := append([]float64(nil), data...)
 
-func BenchmarkQuickSelectSize1e6K1e1(b *testing.B) { bench(b, 1e6, 1e1, true) }
-func BenchmarkQuickSelectSize1e6K1e2(b *testing.B) { bench(b, 1e6, 1e2, true) }
-func BenchmarkQuickSelectSize1e6K1e3(b *testing.B) { bench(b, 1e6, 1e3, true) }
-func BenchmarkQuickSelectSize1e6K1e4(b *testing.B) { bench(b, 1e6, 1e4, true) }
-func BenchmarkQuickSelectSize1e6K1e5(b *testing.B) { bench(b, 1e6, 1e5, true) }
+	selected := SelectTopKWithIndices(data, 4)
+	if len(selected) != 4 {
+		t.Fatalf("Expected 4 selected elements, but got %d", len(selected))
+	}
+	indices := make([]int, len(selected))
+	for i, elem := range selected {
+		if elem.Value != data[i] || elem.Value != original[elem.Index] {
+			t.Errorf("Expected element %d to be data[%d] and original[%d], but got %v", i, i, elem.Index, elem)
+		}
+		indices[i] = elem.Index
+	}
+	if !hasSameElements(indices, []int{2, 5, 7, 9}) {
+		t.Errorf("Expected original indices to be '%v', but got '%v'", []int{2, 5, 7, 9}, indices)
+	}
 
-func BenchmarkQuickSelectSize1e7K1e1(b *testing.B) { bench(b, 1e7, 1e1, true) }
-func BenchmarkQuickSelectSize1e7K1e2(b *testing.B) { bench(b, 1e7, 1e2, true) }
-func BenchmarkQuickSelectSize1e7K1e3(b *testing.B) { bench(b, 1e7, 1e3, true) }
-func BenchmarkQuickSelectSize1e7K1e4(b *testing.B) { bench(b, 1e7, 1e4, true) }
-func BenchmarkQuickSelectSize1e7K1e5(b *testing.B) { bench(b, 1e7, 1e5, true) }
-func BenchmarkQuickSelectSize1e7K1e6(b *testing.B) { bench(b, 1e7, 1e6, true) }
+	if selected := SelectTopKWithIndices(data, 0); len(selected) != 0 {
+		t.Errorf("Expected nothing to be selected for k = 0, but got '%v'", selected)
+	}
 
-func BenchmarkQuickSelectSize1e8K1e1(b *testing.B) { bench(b, 1e8, 1e1, true) }
-func BenchmarkQuickSelectSize1e8K1e2(b *testing.B) { bench(b, 1e8, 1e2, true) }
-func BenchmarkQuickSelectSize1e8K1e3(b *testing.B) { bench(b, 1e8, 1e3, true) }
-func BenchmarkQuickSelectSize1e8K1e4(b *testing.B) { bench(b, 1e8, 1e4, true) }
-func BenchmarkQuickSelectSize1e8K1e5(b *testing.B) { bench(b, 1e8, 1e5, true) }
-func BenchmarkQuickSelectSize1e8K1e6(b *testing.B) { bench(b, 1e8, 1e6, true) }
-func BenchmarkQuickSelectSize1e8K1e7(b *testing.B) { bench(b, 1e8, 1e7, true) }
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Should have panicked on index outside of array length.")
+		}
+	}()
+	SelectTopKWithIndices(data, len(data)+1)
+}
+
+func TestSelectMulti(t *testing.T) {
+	products := []product{
+		{"stapler", 5}, {"mug", 3}, {"pen", 1}, {"lamp", 5}, {"desk", 90},
+		{"clip", 5}, {"tape", 3}, {"ink", 5}, {"book", 12},
+	}
+
+	for run := 0; run < 20; run++ {
+		data := append(byPrice(nil), products...)
+		rand.Shuffle(len(data), data.Swap)
+
+		err := SelectMulti(data, 5, func(i, j int) bool { return data[i].Name < data[j].Name })
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+
+		names := make(map[string]bool)
+		for _, p := range data[:5] {
+			names[p.Name] = true
+		}
+		for _, name := range []string{"pen", "mug", "tape", "clip", "ink"} {
+			if !names[name] {
+				t.Fatalf("Expected '%s' to be among the 5 cheapest products, but got %v", name, data[:5])
+			}
+		}
+	}
+}
+
+func TestIntSelectKth(t *testing.T) {
+	fixtures := []struct {
+		Array    []int
+		K        int
+		Expected int
+	}{
+		{[]int{0, 14, 16, 29, 12, 2, 4, 4, 7, 29}, 1, 0},
+		{[]int{0, 14, 16, 29, 12, 2, 4, 4, 7, 29}, 4, 4},
+		{[]int{0, 14, 16, 29, 12, 2, 4, 4, 7, 29}, 10, 29},
+		{[]int{16, 29, -11, 25, 28, -14, 10, 4, 7, -27}, 3, -11},
+		{[]int{5}, 1, 5},
+	}
+
+	for _, fixture := range fixtures {
+		kth, err := IntSelectKth(fixture.Array, fixture.K)
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if kth != fixture.Expected {
+			t.Errorf("Expected k-th element to be '%d', but got '%d'", fixture.Expected, kth)
+		}
+		if fixture.Array[fixture.K-1] != kth {
+			t.Errorf("Expected k-th element at index %d, but got '%v'", fixture.K-1, fixture.Array)
+		}
+	}
+
+	if _, err := IntSelectKth([]int{1, 2}, 3); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestFloat64AndStringSelectKth(t *testing.T) {
+	f, err := Float64SelectKth([]float64{16.1, 29.3, -11.5, 25.3, 28.8, -14.7}, 2)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if f != -11.5 {
+		t.Errorf("Expected k-th element to be '%g', but got '%g'", -11.5, f)
+	}
+
+	s, err := StringSelectKth([]string{"pear", "apple", "fig", "banana"}, 4)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if s != "pear" {
+		t.Errorf("Expected k-th element to be '%s', but got '%s'", "pear", s)
+	}
+}
+
+func TestQuickSelectLargest(t *testing.T) {
+	ints := []int{16, 29, -11, 25, 28, -14, 10, 4, 7, -27}
+	err := IntQuickSelectLargest(ints, 3)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElements(ints[:3], []int{29, 28, 25}) {
+		t.Errorf("Expected largest K elements to be '%v', but got '%v'", []int{29, 28, 25}, ints[:3])
+	}
+
+	floats := []float64{0.0, 14.3, 16.5, 29.7, 12.6, 2.4, 4.9, 4.2, 7.1, 29.3}
+	err = Float64QuickSelectLargest(floats, 2)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElementsFloat64(floats[:2], []float64{29.7, 29.3}) {
+		t.Errorf("Expected largest K elements to be '%v', but got '%v'", []float64{29.7, 29.3}, floats[:2])
+	}
+
+	strings := []string{"pear", "apple", "fig", "banana"}
+	err = StringQuickSelectLargest(strings, 1)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if strings[0] != "pear" {
+		t.Errorf("Expected largest element to be '%s', but got '%s'", "pear", strings[0])
+	}
+
+	nans := []float64{math.NaN(), 3, math.Inf(-1), math.NaN(), 8, -2}
+	if err := Float64QuickSelectLargest(nans, 4); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElementsFloat64(nans[:4], []float64{8, 3, -2, math.Inf(-1)}) {
+		t.Errorf("Expected NaNs to be selected last, but got '%v'", nans[:4])
+	}
+
+	// The typed helpers select the same elements as QuickSelect on Reverse.
+	data := make([]float64, 5000)
+	for i := range data {
+		data[i] = float64((i * 7919) % 613)
+		if i%97 == 0 {
+			data[i] = math.NaN()
+		}
+	}
+	for _, k := range []int{0, 1, 10, 2500, 4950, 4999, 5000} {
+		direct := append([]float64(nil), data...)
+		reversed := append(Float64Slice(nil), data...)
+		if err := Float64QuickSelectLargest(direct, k); err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		QuickSelect(Reverse(reversed), k)
+		if !hasSameElementsFloat64(direct[:k], reversed[:k]) {
+			t.Errorf("Expected Float64QuickSelectLargest to select the same %d elements as QuickSelect on Reverse", k)
+		}
+	}
+
+	if err := IntQuickSelectLargest(ints, 11); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestSliceQuickSelectLargest(t *testing.T) {
+	ints := IntSlice{16, 29, -11, 25, 28, -14, 10, 4, 7, -27}
+	if err := ints.QuickSelectLargest(3); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElements(ints[:3], []int{29, 28, 25}) {
+		t.Errorf("Expected largest K elements to be '%v', but got '%v'", []int{29, 28, 25}, ints[:3])
+	}
+
+	floats := Float64Slice{0.0, math.NaN(), 14.3, 16.5, 29.7, 12.6, 2.4, math.NaN(), 29.3}
+	if err := floats.QuickSelectLargest(7); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElementsFloat64(floats[:7], []float64{0.0, 14.3, 16.5, 29.7, 12.6, 2.4, 29.3}) {
+		t.Errorf("Expected NaNs to be selected last, but got '%v'", floats[:7])
+	}
+
+	strings := StringSlice{"pear", "apple", "fig", "banana"}
+	if err := strings.QuickSelectLargest(2); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElementsOrdered(strings[:2], []string{"pear", "fig"}) {
+		t.Errorf("Expected largest K elements to be '%v', but got '%v'", []string{"pear", "fig"}, strings[:2])
+	}
+
+	bytes := ByteSlice("quickselect")
+	if err := bytes.QuickSelectLargest(2); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElementsOrdered(bytes[:2], []byte("ut")) {
+		t.Errorf("Expected largest K elements to be '%s', but got '%s'", "ut", bytes[:2])
+	}
+
+	epoch := time.Unix(0, 0)
+	times := TimeSlice{epoch.Add(3 * time.Hour), epoch, epoch.Add(time.Hour), epoch.Add(2 * time.Hour)}
+	if err := times.QuickSelectLargest(1); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !times[0].Equal(epoch.Add(3 * time.Hour)) {
+		t.Errorf("Expected the latest time first, but got '%v'", times[0])
+	}
+
+	if err := ints.QuickSelectLargest(11); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestMultiSelect(t *testing.T) {
+	data := IntSlice(randomInts(1000, 500, 7))
+	sorted := append([]int(nil), data...)
+	sort.Ints(sorted)
+
+	ks := []int{1, 2, 10, 500, 501, 900, 990, 1000}
+	err := MultiSelect(data, ks)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+
+	for _, k := range ks {
+		if data[k-1] != sorted[k-1] {
+			t.Errorf("Expected k-th element for k=%d to be '%d', but got '%d'", k, sorted[k-1], data[k-1])
+		}
+		if !hasSameElements(data[:k], sorted[:k]) {
+			t.Errorf("Expected the first %d elements to be the smallest", k)
+		}
+	}
+}
+
+func TestMultiSelectErrors(t *testing.T) {
+	fixtures := [][]int{
+		{0, 2},
+		{1, 6},
+		{3, 2},
+		{2, 2},
+	}
+
+	for _, ks := range fixtures {
+		if err := MultiSelect(IntSlice{5, 4, 3, 2, 1}, ks); err == nil {
+			t.Errorf("Should have raised error on indices '%v'.", ks)
+		}
+	}
+}
+
+func TestIntSmallestK(t *testing.T) {
+	data := []int{16, 29, -11, 25, 28, -14, 10, 4, 7, -27}
+	original := append([]int(nil), data...)
+
+	smallestK, err := IntSmallestK(data, 4)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElements(smallestK, []int{-27, -11, -14, 4}) {
+		t.Errorf("Expected smallest K elements to be '%v', but got '%v'", []int{-27, -11, -14, 4}, smallestK)
+	}
+	for i := range data {
+		if data[i] != original[i] {
+			t.Errorf("Expected data to be left untouched as '%v', but got '%v'", original, data)
+			break
+		}
+	}
+
+	if _, err := IntSmallestK(data, 11); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestIntSmallestKSorted(t *testing.T) {
+	data := []int{16, 29, -11, 25, 28, -14, 10, 4, 7, -27}
+	original := append([]int(nil), data...)
+
+	smallestK, err := IntSmallestKSorted(data, 4)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	expectedK := []int{-27, -14, -11, 4}
+	if len(smallestK) != len(expectedK) {
+		t.Fatalf("Expected sorted smallest K elements to be '%v', but got '%v'", expectedK, smallestK)
+	}
+	for i := range expectedK {
+		if smallestK[i] != expectedK[i] {
+			t.Errorf("Expected sorted smallest K elements to be '%v', but got '%v'", expectedK, smallestK)
+			break
+		}
+	}
+	for i := range data {
+		if data[i] != original[i] {
+			t.Errorf("Expected data to be left untouched as '%v', but got '%v'", original, data)
+			break
+		}
+	}
+
+	if _, err := IntSmallestKSorted(data, -1); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestIntTopKSortedDesc(t *testing.T) {
+	fixtures := []struct {
+		Data     []int
+		K        int
+		Expected []int
+	}{
+		{[]int{16, 29, -11, 25, 28, -14, 10, 4, 7, -27}, 4, []int{29, 28, 25, 16}},
+		{[]int{5, 9, 9, 1, 9, 3, 7}, 2, []int{9, 9}},
+		{[]int{5, 9, 9, 1, 9, 3, 7}, 4, []int{9, 9, 9, 7}},
+		{[]int{3, 3, 3, 3}, 3, []int{3, 3, 3}},
+		{[]int{2, 1}, 2, []int{2, 1}},
+		{[]int{2, 1}, 0, []int{}},
+	}
+
+	for _, fixture := range fixtures {
+		original := append([]int(nil), fixture.Data...)
+		largestK, err := IntTopKSortedDesc(fixture.Data, fixture.K)
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if len(largestK) != len(fixture.Expected) {
+			t.Errorf("Expected sorted largest K elements to be '%v', but got '%v'", fixture.Expected, largestK)
+			continue
+		}
+		for i := range fixture.Expected {
+			if largestK[i] != fixture.Expected[i] {
+				t.Errorf("Expected sorted largest K elements to be '%v', but got '%v'", fixture.Expected, largestK)
+				break
+			}
+		}
+		for i := range original {
+			if fixture.Data[i] != original[i] {
+				t.Errorf("Expected data to be left untouched as '%v', but got '%v'", original, fixture.Data)
+				break
+			}
+		}
+	}
+
+	if _, err := IntTopKSortedDesc([]int{1, 2}, 3); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestIntSelectInto(t *testing.T) {
+	src := make([]int, 10000)
+	for i := range src {
+		src[i] = rand.IntN(5000) - 2500
+	}
+	original := append([]int(nil), src...)
+	sorted := append([]int(nil), src...)
+	sort.Ints(sorted)
+
+	dst := make([]int, 0, 64)
+	for _, k := range []int{0, 1, 7, 64} {
+		smallestK, err := IntSelectInto(dst, src, k)
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if !hasSameElements(smallestK, sorted[:k]) {
+			t.Errorf("Expected smallest K elements to be '%v', but got '%v'", sorted[:k], smallestK)
+		}
+		if k > 0 && &smallestK[0] != &dst[:1][0] {
+			t.Errorf("Expected the destination's array to be reused")
+		}
+	}
+	for i := range src {
+		if src[i] != original[i] {
+			t.Errorf("Expected src to be left untouched")
+			break
+		}
+	}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		IntSelectInto(dst, src, 64)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, but got %g", allocs)
+	}
+
+	if _, err := IntSelectInto(dst, src, 65); err == nil {
+		t.Errorf("Should have raised error on a destination that's too small.")
+	}
+	if _, err := IntSelectInto(dst, src[:3], 4); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestQuickSelectSorted(t *testing.T) {
+	data := IntSlice{16, 29, -11, 25, 28, -14, 10, 4, 7, -27, 3, 12}
+	err := QuickSelectSorted(data, 5)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	expectedK := []int{-27, -14, -11, 3, 4}
+	for i := range expectedK {
+		if data[i] != expectedK[i] {
+			t.Errorf("Expected sorted smallest K elements to be '%v', but got '%v'", expectedK, data[:5])
+			break
+		}
+	}
+
+	err = QuickSelectSorted(Reverse(data), 3)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	expectedK = []int{29, 28, 25}
+	for i := range expectedK {
+		if data[i] != expectedK[i] {
+			t.Errorf("Expected sorted largest K elements to be '%v', but got '%v'", expectedK, data[:3])
+			break
+		}
+	}
+
+	if err := QuickSelectSorted(data, -1); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestQuickSelectContext(t *testing.T) {
+	data := make(IntSlice, 1e6)
+	for i := range data {
+		data[i] = len(data) - i
+	}
+
+	err := QuickSelectContext(context.Background(), data, 1000)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	for _, elem := range data[:1000] {
+		if elem > 1000 {
+			t.Errorf("Expected smallest K elements to be at most 1000, but got '%d'", elem)
+			break
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, k := range []int{1, 10, 1000, 500000} {
+		err = QuickSelectContext(ctx, data, k)
+		if err != context.Canceled {
+			t.Errorf("Expected '%v' for k=%d, but got '%v'", context.Canceled, k, err)
+		}
+	}
+}
+
+func TestSelectionCheckpoint(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s := &selection{ctx: ctx}
+
+	if err := s.checkpoint(checkpointInterval - 1); err != nil {
+		t.Errorf("Shouldn't have checked the context before the interval, but got '%v'", err)
+	}
+	if err := s.checkpoint(1); err != context.Canceled {
+		t.Errorf("Expected '%v' once the interval was reached, but got '%v'", context.Canceled, err)
+	}
+
+	data := make(IntSlice, 1e6)
+	if err := s.heapSelectionFinding(data, 10, len(data)); err != context.Canceled {
+		t.Errorf("Expected heap selection to stop with '%v', but got '%v'", context.Canceled, err)
+	}
+	if err := s.randomizedSelectionFinding(data, 0, len(data)-1, 5e5); err != context.Canceled {
+		t.Errorf("Expected randomized selection to stop with '%v', but got '%v'", context.Canceled, err)
+	}
+}
+
+func TestQuickSelectSeeded(t *testing.T) {
+	data := randomInts(10000, 1000, 42)
+	sorted := append([]int(nil), data...)
+	sort.Ints(sorted)
+
+	first := append(IntSlice(nil), data...)
+	second := append(IntSlice(nil), data...)
+	if err := QuickSelectSeeded(first, 5000, rand.NewPCG(1, 2)); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if err := QuickSelectSeeded(second, 5000, rand.NewPCG(1, 2)); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+
+	if !hasSameElements(first[:5000], sorted[:5000]) {
+		t.Errorf("Expected the first 5000 elements to be the smallest")
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("Expected equally seeded selections to produce the same order, but they differ at index %d", i)
+			break
+		}
+	}
+}
+
+func TestSelectRange(t *testing.T) {
+	data := randomInts(1000, 300, 5)
+	sorted := append([]int(nil), data...)
+	sort.Ints(sorted)
+
+	fixtures := []struct{ I, J int }{
+		{100, 110},
+		{0, 10},
+		{990, 1000},
+		{0, 1000},
+		{500, 501},
+	}
+
+	for _, fixture := range fixtures {
+		array := append(IntSlice(nil), data...)
+		err := SelectRange(array, fixture.I, fixture.J)
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+
+		if !hasSameElements(array[:fixture.I], sorted[:fixture.I]) ||
+			!hasSameElements(array[fixture.I:fixture.J], sorted[fixture.I:fixture.J]) {
+			t.Errorf("Expected data[%d:%d] to hold the elements of those ranks, but got '%v'", fixture.I, fixture.J, array[fixture.I:fixture.J])
+		}
+	}
+
+	for _, fixture := range []struct{ I, J int }{{-1, 2}, {3, 3}, {4, 2}, {0, 1001}} {
+		if err := SelectRange(IntSlice(data), fixture.I, fixture.J); err == nil {
+			t.Errorf("Should have raised error on range [%d,%d).", fixture.I, fixture.J)
+		}
+	}
+}
+
+func TestSelectInterface(t *testing.T) {
+	ints := sort.IntSlice{16, 29, -11, 25, 28, -14, 10, 4, 7, -27}
+	lo, hi := SelectInterface(ints, 4)
+	if lo != 0 || hi != 4 {
+		t.Errorf("Expected block bounds to be [0,4), but got [%d,%d)", lo, hi)
+	}
+	if !hasSameElements(ints[lo:hi], []int{-27, -11, -14, 4}) {
+		t.Errorf("Expected smallest K elements to be '%v', but got '%v'", []int{-27, -11, -14, 4}, ints[lo:hi])
+	}
+
+	floats := sort.Float64Slice{2.5, math.NaN(), -1, 7}
+	lo, hi = SelectInterface(floats, 2)
+	if !math.IsNaN(floats[0]) && !math.IsNaN(floats[1]) || floats[0] != -1 && floats[1] != -1 {
+		t.Errorf("Expected smallest K elements to be NaN and -1, but got '%v'", floats[lo:hi])
+	}
+
+	strings := sort.StringSlice{"pear", "apple", "fig"}
+	if lo, hi := SelectInterface(strings, 0); lo != 0 || hi != 0 {
+		t.Errorf("Expected an empty block for k = 0, but got [%d,%d)", lo, hi)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Should have panicked on index outside of array length.")
+		}
+	}()
+	SelectInterface(strings, 4)
+}
+
+func TestSelectRangeIndices(t *testing.T) {
+	data := randomInts(3000, 300, 9)
+	// Small elements in front of the range must not be mistaken for ones an
+	// earlier selection placed there.
+	for i := 0; i < 1000; i++ {
+		data[i] = -1
+	}
+
+	fixtures := []struct{ Start, End, K int }{
+		{1000, 2000, 10},
+		{1000, 2000, 500},
+		{1000, 2000, 999},
+		{1000, 2000, 0},
+		{1000, 2000, 1000},
+		{0, 3000, 1500},
+		{2990, 3000, 3},
+		{1500, 1500, 0},
+	}
+
+	for _, fixture := range fixtures {
+		array := append([]int(nil), data...)
+		payload := make([]int, len(array))
+		for i := range payload {
+			payload[i] = i
+		}
+		lo, hi := SelectRangeIndices(lessSwap{
+			n:    len(array),
+			less: func(i, j int) bool { return array[i] < array[j] },
+			swap: func(i, j int) {
+				array[i], array[j] = array[j], array[i]
+				payload[i], payload[j] = payload[j], payload[i]
+			},
+		}, fixture.Start, fixture.End, fixture.K)
+		if lo != fixture.Start || hi != fixture.Start+fixture.K {
+			t.Errorf("Expected block bounds to be [%d,%d), but got [%d,%d)", fixture.Start, fixture.Start+fixture.K, lo, hi)
+			continue
+		}
+
+		sorted := append([]int(nil), data[fixture.Start:fixture.End]...)
+		sort.Ints(sorted)
+		if !hasSameElements(array[lo:hi], sorted[:fixture.K]) || !hasSameElements(array[fixture.Start:fixture.End], sorted) {
+			t.Errorf("Expected data[%d:%d] to hold the %d smallest elements of [%d,%d)", lo, hi, fixture.K, fixture.Start, fixture.End)
+		}
+		for i := range array {
+			if (i < fixture.Start || i >= fixture.End) && array[i] != data[i] || data[payload[i]] != array[i] {
+				t.Errorf("Expected elements outside of [%d,%d) to stay put and payloads to follow their elements", fixture.Start, fixture.End)
+				break
+			}
+		}
+	}
+
+	for _, fixture := range []struct{ Start, End, K int }{{-1, 2, 1}, {4, 2, 0}, {0, 3001, 1}, {10, 20, 11}, {10, 20, -1}} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Should have raised error on range [%d,%d) with k = %d.", fixture.Start, fixture.End, fixture.K)
+				}
+			}()
+			SelectRangeIndices(IntSlice(data), fixture.Start, fixture.End, fixture.K)
+		}()
+	}
+}
+
+func TestSelect(t *testing.T) {
+	fixture := TestData{[]int{2, 10, 5, 3, 2, 6, 2, 6, 10, 3, 4, 5}}
+	lo, hi := Select(fixture, 4)
+	if lo != 0 || hi != 4 {
+		t.Errorf("Expected block bounds to be [0,4), but got [%d,%d)", lo, hi)
+	}
+
+	expectedK := []int{2, 2, 2, 3}
+	if !hasSameElements(fixture.Array[lo:hi], expectedK) {
+		t.Errorf("Expected smallest K elements to be '%v', but got '%v'", expectedK, fixture.Array[lo:hi])
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Should have panicked on index outside of array length.")
+		}
+	}()
+	Select(fixture, 13)
+}
+
+func TestSelector(t *testing.T) {
+	selector := NewSelector()
+	for _, fixture := range []struct {
+		Array     IntSlice
+		K         int
+		ExpectedK []int
+	}{
+		{[]int{0, 14, 16, 29, 12, 2, 4, 4, 7, 29}, 4, []int{0, 2, 4, 4}},
+		{[]int{16, 29, -11, 25, 28, -14, 10, 4, 7, -27}, 2, []int{-27, -14}},
+		{make([]int, 5000), 3, []int{0, 0, 0}},
+	} {
+		err := selector.Select(fixture.Array, fixture.K)
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if !hasSameElements(fixture.Array[:fixture.K], fixture.ExpectedK) {
+			t.Errorf("Expected smallest K elements to be '%v', but got '%v'", fixture.ExpectedK, fixture.Array[:fixture.K])
+		}
+	}
+
+	var small, large Interface = make(IntSlice, 50), make(IntSlice, 5000)
+	allocs := testing.AllocsPerRun(100, func() {
+		selector.Select(small, 10)
+		selector.Select(large, 4)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected a warmed up Selector not to allocate, but got %v allocations per run", allocs)
+	}
+}
+
+func TestChooseStrategy(t *testing.T) {
+	fixtures := []struct {
+		Length, K int
+		Expected  strategy
+	}{
+		{10, 1, naiveStrategy},
+		{100, 10, naiveStrategy},
+		{100, 11, randomizedStrategy},
+		{101, 10, randomizedStrategy},
+		{1e4, 10, heapStrategy},
+		{1e6, 1000, heapStrategy},
+		{1e6, 1001, randomizedStrategy},
+		{1e7, 1001, randomizedStrategy},
+		{1e4, 5000, randomizedStrategy},
+	}
+
+	for _, fixture := range fixtures {
+		chosen := chooseStrategy(fixture.Length, fixture.K)
+		if chosen != fixture.Expected {
+			t.Errorf("Expected strategy %d for length %d and k %d, but got %d", fixture.Expected, fixture.Length, fixture.K, chosen)
+		}
+	}
+}
+
+func TestTunableThresholds(t *testing.T) {
+	defer func(length, naive, partition int) {
+		NaiveSelectionLengthThreshold, NaiveSelectionThreshold, PartitionThreshold = length, naive, partition
+	}(NaiveSelectionLengthThreshold, NaiveSelectionThreshold, PartitionThreshold)
+
+	NaiveSelectionLengthThreshold, NaiveSelectionThreshold = 1000, 100
+	if chosen := chooseStrategy(500, 50); chosen != naiveStrategy {
+		t.Errorf("Expected strategy %d after raising the naive thresholds, but got %d", naiveStrategy, chosen)
+	}
+
+	for _, threshold := range []int{0, 1, 64} {
+		PartitionThreshold = threshold
+		array := make([]int, 1000)
+		for i := range array {
+			array[i] = rand.IntN(100)
+		}
+		expected := append([]int(nil), array...)
+		sort.Ints(expected)
+
+		if err := new(selection).randomizedSelectionFinding(IntSlice(array), 0, len(array)-1, 300); err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if !hasSameElements(array[:300], expected[:300]) {
+			t.Errorf("Wrong smallest 300 elements with a partition threshold of %d", threshold)
+		}
+	}
+}
+
+func TestQuickSelectAllDuplicates(t *testing.T) {
+	size := int(1e6)
+	for _, k := range []int{2, size / 3, size - 1} {
+		data := &lessCounter{Interface: make(IntSlice, size)}
+		if err := new(selection).randomizedSelectionFinding(data, 0, size-1, k); err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		// One pass to partition around the first pivot, which puts it first,
+		// and one more to find that the rest of the data equals it.
+		if data.compares > 2*size+1 {
+			t.Errorf("Expected at most %d comparisons for k = %d, but got %d", 2*size+1, k, data.compares)
+		}
+
+		ints := make([]int, size)
+		if err := IntQuickSelect(ints, k); err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+	}
+}
+
+func TestPartitionEqual(t *testing.T) {
+	data := IntSlice{3, 9, 3, 5, 3, 4, 3}
+	partitionIndex := partitionEqual(data, 1, 6, 4)
+	if partitionIndex != 3 {
+		t.Errorf("Expected partition index 3, but got %d", partitionIndex)
+	}
+	expected := []int{3, 3, 3, 3, 5, 4, 9}
+	if !hasSameElements(data[1:4], expected[1:4]) || !hasSameElements(data[4:], expected[4:]) {
+		t.Errorf("Expected '%v' to be partitioned like '%v'", data, expected)
+	}
+}
+
+func TestPartitionSmallRanges(t *testing.T) {
+	// Every 2- and 3-element range over the values {1, 2, 3}, duplicates
+	// included, with the pivot at every position, between two sentinels that
+	// must be left alone.
+	var ranges [][]int
+	for _, n := range []int{2, 3} {
+		values := make([]int, n)
+		var fill func(i int)
+		fill = func(i int) {
+			if i == n {
+				ranges = append(ranges, append([]int(nil), values...))
+				return
+			}
+			for v := 1; v <= 3; v++ {
+				values[i] = v
+				fill(i + 1)
+			}
+		}
+		fill(0)
+	}
+
+	for _, r := range ranges {
+		low, high := 1, len(r)
+		for pivotIndex := low; pivotIndex <= high; pivotIndex++ {
+			data := append(append(IntSlice{-100}, r...), 100)
+			ordered := append([]int(nil), data...)
+			pivot := data[pivotIndex]
+
+			partitionIndex := partition(data, low, high, pivotIndex)
+			if orderedIndex := orderedPartition(ordered, low, high, pivotIndex); orderedIndex != partitionIndex {
+				t.Errorf("Expected orderedPartition to return %d like partition on '%v', but got %d", partitionIndex, r, orderedIndex)
+			}
+
+			if partitionIndex < low || partitionIndex > high || data[partitionIndex] != pivot {
+				t.Errorf("Expected pivot %d of '%v' to end up within [%d,%d], but got index %d in '%v'", pivot, r, low, high, partitionIndex, data)
+				continue
+			}
+			if data[0] != -100 || data[len(data)-1] != 100 {
+				t.Errorf("Expected partition of '%v' to stay within its range, but got '%v'", r, data)
+			}
+			if !hasSameElements(data[low:high+1], r) {
+				t.Errorf("Expected partition of '%v' to keep its elements, but got '%v'", r, data[low:high+1])
+			}
+			for i := low; i <= high; i++ {
+				if i < partitionIndex && data[i] >= pivot || i > partitionIndex && data[i] < pivot {
+					t.Errorf("Expected '%v' to be partitioned around %d at index %d, but got '%v'", r, pivot, partitionIndex, data)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestSelectionStackDepth(t *testing.T) {
+	size := 1 << 20
+	if testing.Short() {
+		size = 1 << 16
+	}
+
+	orderings := map[string]func(i int) int{
+		"sorted":     func(i int) int { return i },
+		"reversed":   func(i int) int { return size - i },
+		"equal":      func(i int) int { return 7 },
+		"organ pipe": func(i int) int { return min(i, size-i) },
+		"sawtooth":   func(i int) int { return i % 64 },
+	}
+	ks := make([]int, 0, size/64)
+	for k := 1; k <= size; k += 64 {
+		ks = append(ks, k)
+	}
+	selections := map[string]func(data IntSlice) error{
+		"QuickSelect":       func(data IntSlice) error { return QuickSelect(data, size/2) },
+		"IntQuickSelect":    func(data IntSlice) error { return IntQuickSelect(data, size/2) },
+		"heapsort fallback": func(data IntSlice) error { return QuickSelectWithOptions(data, size/2, Options{Limit: -1}) },
+		"median of medians": func(data IntSlice) error {
+			return QuickSelectWithOptions(data, size/2, Options{Limit: -1, Fallback: MedianOfMediansFallback})
+		},
+		"QuickSelectDeterministic": func(data IntSlice) error { return QuickSelectDeterministic(data, size/2) },
+		"MultiSelect":              func(data IntSlice) error { return MultiSelect(data, ks) },
+	}
+
+	// A selection whose recursion depth grows with the data rather than with
+	// its logarithm would overflow this and crash the test binary.
+	defer debug.SetMaxStack(debug.SetMaxStack(256 << 10))
+
+	data := make(IntSlice, size)
+	for orderingName, ordering := range orderings {
+		for selectionName, selection := range selections {
+			for i := range data {
+				data[i] = ordering(i)
+			}
+			if err := selection(data); err != nil {
+				t.Errorf("Shouldn't have raised error for %s on %s data: '%s'", selectionName, orderingName, err.Error())
+			}
+		}
+	}
+}
+
+// growingLen reports the true length on the first call to Len, and twice that
+// on every later one, like a live view that's appended to mid selection.
+type growingLen struct {
+	IntSlice
+	calls *int
+}
+
+func (g growingLen) Len() int {
+	if *g.calls++; *g.calls > 1 {
+		return 2 * len(g.IntSlice)
+	}
+	return len(g.IntSlice)
+}
+
+func TestLenCalledOnce(t *testing.T) {
+	entryPoints := map[string]func(data Interface, k int){
+		"QuickSelect":              func(data Interface, k int) { QuickSelect(data, k) },
+		"Select":                   func(data Interface, k int) { Select(data, k) },
+		"SelectThreshold":          func(data Interface, k int) { SelectThreshold(data, k) },
+		"SelectSplit":              func(data Interface, k int) { SelectSplit(data, k) },
+		"HeapSelect":               func(data Interface, k int) { HeapSelect(data, k) },
+		"SelectSmallK":             func(data Interface, k int) { SelectSmallK(data, k) },
+		"SelectMinSwaps":           func(data Interface, k int) { SelectMinSwaps(data, k) },
+		"StableSelect":             func(data Interface, k int) { StableSelect(data, k) },
+		"QuickSelectDeterministic": func(data Interface, k int) { QuickSelectDeterministic(data, k) },
+		"QuickSelectWithOptions":   func(data Interface, k int) { QuickSelectWithOptions(data, k, Options{SwapCostHint: ExpensiveSwap}) },
+		"SelectWithStats":          func(data Interface, k int) { SelectWithStats(data, k) },
+		"SelectLenient":            func(data Interface, k int) { SelectLenient(data, k) },
+		"SelectWithProgress":       func(data Interface, k int) { SelectWithProgress(data, k, func(done, total int) {}) },
+		"SelectOrAll":              func(data Interface, k int) { SelectOrAll(data, k) },
+		"SelectSnapshot":           func(data Interface, k int) { SelectSnapshot(data, k) },
+		"SelectIndices":            func(data Interface, k int) { SelectIndices(data.Len(), k, data.Less) },
+		"MultiSelect":              func(data Interface, k int) { MultiSelect(data, []int{1, k}) },
+		"SelectRange":              func(data Interface, k int) { SelectRange(data, 1, k) },
+		"TrimBounds":               func(data Interface, k int) { TrimBounds(data, 1, k) },
+		"QuickSelectParallel":      func(data Interface, k int) { QuickSelectParallel(data, k, 2) },
+		"QuickSelectContext":       func(data Interface, k int) { QuickSelectContext(context.Background(), data, k) },
+		"SelectSafe":               func(data Interface, k int) { SelectSafe(data, k) },
+		"SelectBounded":            func(data Interface, k int) { SelectBounded(data, k, 1<<30) },
+		"SelectRangeIndices":       func(data Interface, k int) { SelectRangeIndices(data, 1, k+1, k/2) },
+		"SelectPreserveOrder":      func(data Interface, k int) { SelectPreserveOrder(data, k) },
+		"SelectMulti":              func(data Interface, k int) { SelectMulti(data, k, func(i, j int) bool { return i < j }) },
+		"IsNearlySorted":           func(data Interface, k int) { IsNearlySorted(data) },
+		"Min":                      func(data Interface, k int) { Min(data) },
+		"Max":                      func(data Interface, k int) { Max(data) },
+	}
+
+	for name, entryPoint := range entryPoints {
+		for _, size := range []int{50, 5000} {
+			for _, k := range []int{2, 9, size / 2, size - 1} {
+				array := make(IntSlice, size)
+				for i := range array {
+					array[i] = (i * 7919) % size
+				}
+				calls := 0
+
+				func() {
+					defer func() {
+						if r := recover(); r != nil {
+							t.Errorf("Expected %s to keep to the first length of %d for k = %d, but it panicked with '%v'", name, size, k, r)
+						}
+					}()
+					entryPoint(growingLen{array, &calls}, k)
+				}()
+				if calls != 1 {
+					t.Errorf("Expected %s to call Len once, but it was called %d times", name, calls)
+				}
+			}
+		}
+	}
+}
+
+func TestHeapDownWithoutChildren(t *testing.T) {
+	fixtures := []struct{ I, N int }{
+		{0, 0},
+		{0, 1},
+		{1, 2},
+		{1, 3},
+		{math.MaxInt32 / 2, math.MaxInt32},
+		{math.MaxInt32/2 + 1, math.MaxInt32},
+		{math.MaxInt32, math.MaxInt32},
+		{math.MaxInt / 2, math.MaxInt},
+		{math.MaxInt/2 + 1, math.MaxInt},
+		{math.MaxInt - 1, math.MaxInt},
+		{-1, 10},
+	}
+
+	// The heap is too small for any of the fixtures to have children in it,
+	// so heapDown must stop without ever indexing it.
+	data := IntSlice{3, 2, 1}
+	for _, fixture := range fixtures {
+		heap := []int{0, 1, 2}
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("Expected heapDown(%d, %d) to stop at a node without children, but it panicked with '%v'", fixture.I, fixture.N, r)
+				}
+			}()
+			heapDown(data, heap, fixture.I, fixture.N)
+			orderedHeapDown(data, heap, fixture.I, fixture.N)
+		}()
+	}
+
+	heap := []int{2, 0, 1}
+	heapDown(data, heap, 0, 3)
+	if heap[0] != 0 {
+		t.Errorf("Expected the largest element to be sifted to the top, but got '%v'", heap)
+	}
+}
+
+func TestQuickSelectWholeDataStructure(t *testing.T) {
+	fixture := &swapCounter{Interface: IntSlice{50, 20, 30, 25, 45, 2, 6, 10, 3, 4, 5}}
+	err := QuickSelect(fixture, fixture.Len())
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if fixture.swaps != 0 {
+		t.Errorf("Expected selecting every element to leave the data alone, but got %d swaps", fixture.swaps)
+	}
+
+	ints := []int{3, 1, 2}
+	if err := IntQuickSelect(ints, 3); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if ints[0] != 3 || ints[1] != 1 || ints[2] != 2 {
+		t.Errorf("Expected selecting every element to leave the data alone, but got '%v'", ints)
+	}
+}
+
+// swapCounter counts the swaps done on the embedded Interface.
+type swapCounter struct {
+	Interface
+	swaps int
+}
+
+func (s *swapCounter) Swap(i, j int) {
+	s.swaps++
+	s.Interface.Swap(i, j)
+}
+
+func TestSizedIntegerSliceQuickSelect(t *testing.T) {
+	int32s := Int32Slice{16, 29, -11, 25, 28, -14, 10, 4, 7, -27}
+	if err := int32s.QuickSelect(3); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElementsOrdered(int32s[:3], []int32{-27, -14, -11}) {
+		t.Errorf("Expected smallest K elements to be '%v', but got '%v'", []int32{-27, -14, -11}, int32s[:3])
+	}
+
+	int64s := []int64{1 << 40, -1 << 50, 3, 1<<62 + 1, -7}
+	if err := Int64QuickSelect(int64s, 2); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElementsOrdered(int64s[:2], []int64{-1 << 50, -7}) {
+		t.Errorf("Expected smallest K elements to be '%v', but got '%v'", []int64{-1 << 50, -7}, int64s[:2])
+	}
+
+	uints := UintSlice{9, 3, 2, 18, 0}
+	if err := UintQuickSelect(uints, 2); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElementsOrdered(uints[:2], []uint{0, 2}) {
+		t.Errorf("Expected smallest K elements to be '%v', but got '%v'", []uint{0, 2}, uints[:2])
+	}
+
+	uint64s := Uint64Slice{1 << 63, 1<<64 - 1, 5, 1 << 32}
+	if err := uint64s.QuickSelect(2); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElementsOrdered(uint64s[:2], []uint64{5, 1 << 32}) {
+		t.Errorf("Expected smallest K elements to be '%v', but got '%v'", []uint64{5, 1 << 32}, uint64s[:2])
+	}
+
+	if err := QuickSelect(Int64Slice(int64s), 3); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if err := Uint64QuickSelect([]uint64{1}, 2); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+	if err := Int32QuickSelect([]int32{1}, -1); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestByteSliceQuickSelect(t *testing.T) {
+	bytes := ByteSlice{0x80, 0x7f, 0xff, 0x00, 0x10, 0x81}
+	if err := bytes.QuickSelect(3); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElementsOrdered(bytes[:3], []byte{0x00, 0x10, 0x7f}) {
+		t.Errorf("Expected smallest K elements to be '%v', but got '%v'", []byte{0x00, 0x10, 0x7f}, bytes[:3])
+	}
+
+	if (ByteSlice{0x80, 0x7f}).Less(0, 1) {
+		t.Errorf("Expected 0x80 to sort after 0x7f")
+	}
+
+	raw := []byte("quickselect")
+	if err := ByteQuickSelect(raw, 2); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElementsOrdered(raw[:2], []byte("cc")) {
+		t.Errorf("Expected smallest K elements to be '%s', but got '%s'", "cc", raw[:2])
+	}
+}
+
+func TestTimeQuickSelect(t *testing.T) {
+	base := time.Date(2024, 8, 30, 12, 0, 0, 0, time.UTC)
+	offsets := []int{16, 29, -11, 25, 28, -14, 10, 4, 7, -27}
+	times := make([]time.Time, len(offsets))
+	for i, offset := range offsets {
+		times[i] = base.Add(time.Duration(offset) * time.Minute)
+	}
+
+	minutes := func(times []time.Time) []int {
+		offsets := make([]int, len(times))
+		for i, t := range times {
+			offsets[i] = int(t.Sub(base) / time.Minute)
+		}
+		return offsets
+	}
+
+	if err := TimeQuickSelect(times, 3); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElements(minutes(times[:3]), []int{-27, -14, -11}) {
+		t.Errorf("Expected earliest K times to be '%v', but got '%v'", []int{-27, -14, -11}, minutes(times[:3]))
+	}
+
+	if err := TimeQuickSelectLatest(times, 2); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElements(minutes(times[:2]), []int{29, 28}) {
+		t.Errorf("Expected latest K times to be '%v', but got '%v'", []int{29, 28}, minutes(times[:2]))
+	}
+
+	if err := TimeSlice(times).QuickSelect(11); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+func TestSelectReverseWithTiesAtTheBoundary(t *testing.T) {
+	// The sizes are picked so that each of the naive, heap and randomized
+	// strategies gets exercised.
+	for _, size := range []int{12, 100, 20000} {
+		for _, k := range []int{1, 3, 5, 8, 10} {
+			data := make(IntSlice, size)
+			for i := range data {
+				data[i] = i % 7
+			}
+			// Five 7s, then a run of 6s straddling the boundary for k > 5.
+			for i := 0; i < 5; i++ {
+				data[(i*size)/5] = 7
+			}
+			original := append([]int(nil), data...)
+
+			lo, hi := Select(Reverse(data), k)
+			if lo != 0 || hi != k {
+				t.Errorf("Expected block bounds to be [0,%d), but got [%d,%d)", k, lo, hi)
+			}
+
+			sorted := append([]int(nil), original...)
+			sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+			if !hasSameElements(data[lo:hi], sorted[:k]) {
+				t.Errorf("Expected largest %d of %d elements to be '%v', but got '%v'", k, size, sorted[:k], data[lo:hi])
+			}
+			for _, elem := range data[hi:] {
+				if elem > sorted[k-1] {
+					t.Errorf("Expected no element after the block to be larger than '%d', but got '%d'", sorted[k-1], elem)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestPartitionReverse(t *testing.T) {
+	data := IntSlice{5, 1, 5, 9, 3, 5, 7, 5, 2, 8}
+	lt, gt := Partition(Reverse(data), 0, len(data)-1, 0)
+
+	for i := 0; i < lt; i++ {
+		if data[i] <= 5 {
+			t.Errorf("Expected elements in [0,%d) to be greater than 5 when reversed, but got '%v'", lt, data)
+		}
+	}
+	for i := lt; i <= gt; i++ {
+		if data[i] != 5 {
+			t.Errorf("Expected elements in [%d,%d] to equal 5, but got '%v'", lt, gt, data)
+		}
+	}
+	for i := gt + 1; i < len(data); i++ {
+		if data[i] >= 5 {
+			t.Errorf("Expected elements in (%d,%d) to be less than 5 when reversed, but got '%v'", gt, len(data), data)
+		}
+	}
+}
+
+func TestQuickSelectAlreadySelected(t *testing.T) {
+	fixtures := []struct {
+		Array    []int
+		K        int
+		Selected bool
+	}{
+		{[]int{3, 1, 2, 4, 6, 5}, 3, true},
+		{[]int{3, 1, 2, 3, 6, 5}, 3, true},
+		{[]int{3, 1, 4, 2, 6, 5}, 3, false},
+		{[]int{1, 2, 3, 4, 5, 0}, 1, false},
+		{[]int{0, 2, 3, 4, 5, 1}, 1, true},
+	}
+
+	for _, fixture := range fixtures {
+		if selected := isSelected(IntSlice(fixture.Array), fixture.K, len(fixture.Array)); selected != fixture.Selected {
+			t.Errorf("Expected isSelected to be %t for '%v' and k %d, but got %t", fixture.Selected, fixture.Array, fixture.K, selected)
+		}
+		if selected := orderedIsSelected(fixture.Array, fixture.K); selected != fixture.Selected {
+			t.Errorf("Expected orderedIsSelected to be %t for '%v' and k %d, but got %t", fixture.Selected, fixture.Array, fixture.K, selected)
+		}
+	}
+
+	data := make(IntSlice, 10000)
+	for i := range data {
+		data[i] = len(data) - i
+	}
+	if err := QuickSelect(data, 5000); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	data = append(data, 10001, 10002)
+
+	fixture := &swapCounter{Interface: data}
+	if err := QuickSelect(fixture, 5000); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if fixture.swaps != 0 {
+		t.Errorf("Expected selecting already selected data to do no swaps, but got %d", fixture.swaps)
+	}
+}
+
+func TestQuickSelectSmallest(t *testing.T) {
+	fixtures := [][]int{
+		{50, 20, 30, 25, 45, 2, 6, 10, 3, 4, 5},
+		{2, 20, 30, 25, 45, 2, 6, 10, 3, 4, 5},
+		{9},
+		{5, -3, -3, -3},
+	}
+
+	for _, array := range fixtures {
+		minimum := array[0]
+		for _, elem := range array {
+			minimum = min(minimum, elem)
+		}
+
+		fixture := &swapCounter{Interface: IntSlice(append([]int(nil), array...))}
+		if err := QuickSelect(fixture, 1); err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if first := fixture.Interface.(IntSlice)[0]; first != minimum {
+			t.Errorf("Expected smallest element to be '%d', but got '%d'", minimum, first)
+		}
+		if fixture.swaps > 1 {
+			t.Errorf("Expected at most one swap, but got %d", fixture.swaps)
+		}
+
+		ints := append([]int(nil), array...)
+		if err := IntQuickSelect(ints, 1); err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if ints[0] != minimum {
+			t.Errorf("Expected smallest element to be '%d', but got '%d'", minimum, ints[0])
+		}
+	}
+}
+
+func TestSelectThreshold(t *testing.T) {
+	fixtures := []struct {
+		array      []int
+		k          int
+		kth, next  int
+		pivotIsTie bool
+	}{
+		{[]int{50, 20, 30, 25, 45, 2, 6, 10, 3, 4, 5}, 4, 5, 6, false},
+		{[]int{7, 1, 7, 3, 7, 9, 7}, 2, 3, 7, false},
+		{[]int{7, 1, 7, 3, 7, 9, 7}, 3, 7, 7, true},
+		{[]int{4, 4, 4, 4}, 1, 4, 4, true},
+	}
+
+	for _, fixture := range fixtures {
+		data := IntSlice(append([]int(nil), fixture.array...))
+		lo, hi, pivotIsTie := SelectThreshold(data, fixture.k)
+		if lo != 0 || hi != fixture.k {
+			t.Errorf("Expected bounds to be [0, %d), but got [%d, %d)", fixture.k, lo, hi)
+		}
+		if data[hi-1] != fixture.kth || data[hi] != fixture.next {
+			t.Errorf("Expected threshold values '%d' and '%d', but got '%d' and '%d'", fixture.kth, fixture.next, data[hi-1], data[hi])
+		}
+		if pivotIsTie != fixture.pivotIsTie {
+			t.Errorf("Expected pivotIsTie to be %v for k = %d in %v", fixture.pivotIsTie, fixture.k, fixture.array)
+		}
+	}
+
+	data := IntSlice{3, 1, 2}
+	if lo, hi, pivotIsTie := SelectThreshold(data, 3); lo != 0 || hi != 3 || pivotIsTie || data[2] != 3 {
+		t.Errorf("Expected the whole data to be selected with 3 last and no tie, but got [%d, %d) %v %v", lo, hi, pivotIsTie, data)
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	fixtures := []struct {
+		Array    []int
+		Min, Max int
+	}{
+		{[]int{50, 20, 30, 25, 45, 2, 6, 10, 3, 4, 5}, 5, 0},
+		{[]int{7, 1, 9, 1, 9}, 1, 2},
+		{[]int{42}, 0, 0},
+		{[]int{}, -1, -1},
+	}
+
+	for _, fixture := range fixtures {
+		data := &swapCounter{Interface: IntSlice(fixture.Array)}
+		if index := Min(data); index != fixture.Min {
+			t.Errorf("Expected minimum of %v at index %d, but got %d", fixture.Array, fixture.Min, index)
+		}
+		if index := Max(data); index != fixture.Max {
+			t.Errorf("Expected maximum of %v at index %d, but got %d", fixture.Array, fixture.Max, index)
+		}
+		if data.swaps != 0 {
+			t.Errorf("Expected no swaps, but got %d", data.swaps)
+		}
+	}
+}
+
+// Returns n pseudo-random ints in the range [0, limit), which are the same for
+// the same seed, so that failures can be reproduced.
+func randomInts(n, limit int, seed uint64) []int {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	ints := make([]int, n)
+	for i := range ints {
+		ints[i] = rng.IntN(limit)
+	}
+	return ints
+}
+
+// Fills the data with pseudo-random ints in the range [0, limit), continuing
+// the sequence from x. It's cheap enough not to dominate the benchmarks that
+// refill their data between runs.
+func fillPseudoRandom(data []int, x *uint32, limit int) {
+	for i := range data {
+		*x += *x
+		*x ^= 1
+		if int32(*x) < 0 {
+			*x ^= 0x88888eef
+		}
+		data[i] = int(*x % uint32(limit))
+	}
+}
+
+func hasSameElements(array1, array2 []int) bool {
+	elements := make(map[int]int)
+
+	for _, elem1 := range array1 {
+		elements[elem1]++
+	}
+
+	for _, elem2 := range array2 {
+		elements[elem2]--
+	}
+
+	for _, count := range elements {
+		if count != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func hasSameElementsFloat64(array1, array2 []float64) bool {
+	// NaNs never equal each other, so they can't be counted as map keys.
+	elements := make(map[float64]int)
+	nans := 0
+
+	for _, elem1 := range array1 {
+		if math.IsNaN(elem1) {
+			nans++
+		} else {
+			elements[elem1]++
+		}
+	}
+
+	for _, elem2 := range array2 {
+		if math.IsNaN(elem2) {
+			nans--
+		} else {
+			elements[elem2]--
+		}
+	}
+
+	if nans != 0 {
+		return false
+	}
+
+	for _, count := range elements {
+		if count != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func bench(b *testing.B, size, k int, quickselect bool) {
+	b.StopTimer()
+	data := make(IntSlice, size)
+	x := ^uint32(0)
+	for i := 0; i < b.N; i++ {
+		for n := size - 3; n <= size+3; n++ {
+			fillPseudoRandom(data, &x, n/5)
+			if quickselect {
+				b.StartTimer()
+				QuickSelect(data, k)
+				b.StopTimer()
+			} else {
+				b.StartTimer()
+				sort.Sort(data)
+				b.StopTimer()
+			}
+		}
+	}
+}
+
+// Benchmarks for QuickSelect
+func BenchmarkQuickSelectSize1e2K1e1(b *testing.B) { bench(b, 1e2, 1e1, true) }
+
+func BenchmarkQuickSelectSize1e3K1e1(b *testing.B) { bench(b, 1e3, 1e1, true) }
+func BenchmarkQuickSelectSize1e3K1e2(b *testing.B) { bench(b, 1e3, 1e2, true) }
+
+func BenchmarkQuickSelectSize1e4K1e1(b *testing.B) { bench(b, 1e4, 1e1, true) }
+func BenchmarkQuickSelectSize1e4K1e2(b *testing.B) { bench(b, 1e4, 1e2, true) }
+func BenchmarkQuickSelectSize1e4K1e3(b *testing.B) { bench(b, 1e4, 1e3, true) }
+
+func BenchmarkQuickSelectSize1e5K1e1(b *testing.B) { bench(b, 1e5, 1e1, true) }
+func BenchmarkQuickSelectSize1e5K1e2(b *testing.B) { bench(b, 1e5, 1e2, true) }
+func BenchmarkQuickSelectSize1e5K1e3(b *testing.B) { bench(b, 1e5, 1e3, true) }
+func BenchmarkQuickSelectSize1e5K1e4(b *testing.B) { bench(b, 1e5, 1e4, true) }
+
+func BenchmarkQuickSelectSize1e6K1e1(b *testing.B) { bench(b, 1e6, 1e1, true) }
+func BenchmarkQuickSelectSize1e6K1e2(b *testing.B) { bench(b, 1e6, 1e2, true) }
+func BenchmarkQuickSelectSize1e6K1e3(b *testing.B) { bench(b, 1e6, 1e3, true) }
+func BenchmarkQuickSelectSize1e6K1e4(b *testing.B) { bench(b, 1e6, 1e4, true) }
+func BenchmarkQuickSelectSize1e6K1e5(b *testing.B) { bench(b, 1e6, 1e5, true) }
+
+func BenchmarkQuickSelectSize1e7K1e1(b *testing.B) { bench(b, 1e7, 1e1, true) }
+func BenchmarkQuickSelectSize1e7K1e2(b *testing.B) { bench(b, 1e7, 1e2, true) }
+func BenchmarkQuickSelectSize1e7K1e3(b *testing.B) { bench(b, 1e7, 1e3, true) }
+func BenchmarkQuickSelectSize1e7K1e4(b *testing.B) { bench(b, 1e7, 1e4, true) }
+func BenchmarkQuickSelectSize1e7K1e5(b *testing.B) { bench(b, 1e7, 1e5, true) }
+func BenchmarkQuickSelectSize1e7K1e6(b *testing.B) { bench(b, 1e7, 1e6, true) }
+
+func BenchmarkQuickSelectSize1e8K1e1(b *testing.B) { bench(b, 1e8, 1e1, true) }
+func BenchmarkQuickSelectSize1e8K1e2(b *testing.B) { bench(b, 1e8, 1e2, true) }
+func BenchmarkQuickSelectSize1e8K1e3(b *testing.B) { bench(b, 1e8, 1e3, true) }
+func BenchmarkQuickSelectSize1e8K1e4(b *testing.B) { bench(b, 1e8, 1e4, true) }
+func BenchmarkQuickSelectSize1e8K1e5(b *testing.B) { bench(b, 1e8, 1e5, true) }
+func BenchmarkQuickSelectSize1e8K1e6(b *testing.B) { bench(b, 1e8, 1e6, true) }
+func BenchmarkQuickSelectSize1e8K1e7(b *testing.B) { bench(b, 1e8, 1e7, true) }
+
+func benchSelectionFinding(b *testing.B, size int, find func(data IntSlice)) {
+	b.StopTimer()
+	data := make(IntSlice, size)
+	x := ^uint32(0)
+	for i := 0; i < b.N; i++ {
+		fillPseudoRandom(data, &x, size/5)
+		b.StartTimer()
+		find(data)
+		b.StopTimer()
+	}
+}
+
+// Benchmarks for the k == 1 and k == Len() fast paths against the general ones
+func BenchmarkFindMinimumSize1e6(b *testing.B) {
+	benchSelectionFinding(b, 1e6, func(data IntSlice) { QuickSelect(data, 1) })
+}
+func BenchmarkHeapSelectionSize1e6K1(b *testing.B) {
+	benchSelectionFinding(b, 1e6, func(data IntSlice) { new(selection).heapSelectionFinding(data, 1, len(data)) })
+}
+func BenchmarkRandomizedSelectionSize1e6K1(b *testing.B) {
+	benchSelectionFinding(b, 1e6, func(data IntSlice) { new(selection).randomizedSelectionFinding(data, 0, len(data)-1, 0) })
+}
+func BenchmarkQuickSelectSize1e6K1e6(b *testing.B) {
+	benchSelectionFinding(b, 1e6, func(data IntSlice) { QuickSelect(data, 1e6) })
+}
+func BenchmarkRandomizedSelectionSize1e6K1e6(b *testing.B) {
+	benchSelectionFinding(b, 1e6, func(data IntSlice) { new(selection).randomizedSelectionFinding(data, 0, len(data)-1, 1e6-1) })
+}
+
+func BenchmarkRandomizedSelectionAllDuplicatesSize1e6K5e5(b *testing.B) {
+	data := make(IntSlice, 1e6)
+	for i := 0; i < b.N; i++ {
+		new(selection).randomizedSelectionFinding(data, 0, len(data)-1, 5e5)
+	}
+}
 
 // Benchmarks for sorting
 func BenchmarkSortSize1e2K1e1(b *testing.B) { bench(b, 1e2, 1e1, false) }
@@ -0,0 +1,22 @@
+package quickselect
+
+import (
+	"fmt"
+	"reflect"
+)
+
+/*
+ReflectQuickSelect mutates the slice so that its first k elements are the
+smallest k elements, as determined by less. The slice may be of any slice type,
+which makes it useful when the element type isn't known at compile time. Swaps
+go through reflect, so prefer QuickSelectFunc whenever the type is known.
+
+An error is returned if the argument isn't a slice.
+*/
+func ReflectQuickSelect(slice interface{}, k int, less func(i, j int) bool) error {
+	value := reflect.ValueOf(slice)
+	if value.Kind() != reflect.Slice {
+		return fmt.Errorf("The argument must be a slice, but got '%T'", slice)
+	}
+	return QuickSelect(lessSwap{value.Len(), less, reflect.Swapper(slice)}, k)
+}
@@ -0,0 +1,44 @@
+package quickselect
+
+import (
+	"testing"
+)
+
+func TestReflectQuickSelect(t *testing.T) {
+	type order struct {
+		id    int
+		price float64
+	}
+	orders := []order{{1, 9.5}, {2, 3.25}, {3, 7}, {4, 1.5}, {5, 12}, {6, 3.5}}
+
+	var slice interface{} = orders
+	err := ReflectQuickSelect(slice, 3, func(i, j int) bool { return orders[i].price < orders[j].price })
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+
+	ids := make([]int, 3)
+	for i, o := range orders[:3] {
+		ids[i] = o.id
+	}
+	if !hasSameElements(ids, []int{2, 4, 6}) {
+		t.Errorf("Expected smallest orders to be '%v', but got '%v'", []int{2, 4, 6}, ids)
+	}
+}
+
+func TestReflectQuickSelectNotSlice(t *testing.T) {
+	fixtures := []interface{}{nil, 42, "abc", [3]int{3, 2, 1}, map[int]int{1: 1}}
+
+	for _, fixture := range fixtures {
+		if err := ReflectQuickSelect(fixture, 1, func(i, j int) bool { return false }); err == nil {
+			t.Errorf("Should have raised error on '%T', which isn't a slice.", fixture)
+		}
+	}
+}
+
+func TestReflectQuickSelectOutOfRange(t *testing.T) {
+	ints := []int{3, 1, 2}
+	if err := ReflectQuickSelect(ints, 4, func(i, j int) bool { return ints[i] < ints[j] }); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
@@ -0,0 +1,31 @@
+package quickselect
+
+import "container/ring"
+
+/*
+SelectRing returns a newly allocated slice holding the k smallest values of the
+ring, as ordered by less, in no particular order. A ring can't be indexed
+efficiently, so its values are first copied into a slice, which is then
+selected on; the ring itself is left untouched. This is the glue needed for
+windowed analytics over a container/ring sliding window.
+
+Every value in the ring must be either nil, which is skipped as a slot that
+hasn't been filled yet, or hold a T. An error is raised if k exceeds the number
+of values that aren't nil.
+*/
+func SelectRing[T any](r *ring.Ring, k int, less func(a, b T) bool) ([]T, error) {
+	values := make([]T, 0, r.Len())
+	r.Do(func(v any) {
+		if v != nil {
+			values = append(values, v.(T))
+		}
+	})
+
+	err := QuickSelectFunc(len(values), k,
+		func(i, j int) bool { return less(values[i], values[j]) },
+		func(i, j int) { values[i], values[j] = values[j], values[i] })
+	if err != nil {
+		return nil, err
+	}
+	return values[:k:k], nil
+}
@@ -0,0 +1,48 @@
+package quickselect
+
+import (
+	"container/ring"
+	"testing"
+)
+
+func TestSelectRing(t *testing.T) {
+	window := ring.New(6)
+	for _, latency := range []int{120, 35, 80, 240, 15, 95, 60, 20} {
+		window.Value = latency
+		window = window.Next()
+	}
+
+	smallestK, err := SelectRing(window, 3, func(a, b int) bool { return a < b })
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElements(smallestK, []int{15, 20, 60}) {
+		t.Errorf("Expected smallest K elements to be '%v', but got '%v'", []int{15, 20, 60}, smallestK)
+	}
+
+	values := make([]int, 0, window.Len())
+	window.Do(func(v any) { values = append(values, v.(int)) })
+	if !hasSameElements(values, []int{80, 240, 15, 95, 60, 20}) {
+		t.Errorf("Expected the ring to be left untouched, but got '%v'", values)
+	}
+}
+
+func TestSelectRingPartiallyFilled(t *testing.T) {
+	window := ring.New(5)
+	for _, latency := range []int{120, 35} {
+		window.Value = latency
+		window = window.Next()
+	}
+	less := func(a, b int) bool { return a < b }
+
+	smallestK, err := SelectRing(window, 1, less)
+	if err != nil || !hasSameElements(smallestK, []int{35}) {
+		t.Errorf("Expected smallest K elements to be '%v', but got '%v'", []int{35}, smallestK)
+	}
+	if _, err := SelectRing(window, 3, less); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+	if smallestK, err := SelectRing(nil, 0, less); err != nil || len(smallestK) != 0 {
+		t.Errorf("Expected no elements from an empty ring, but got '%v'", smallestK)
+	}
+}
@@ -0,0 +1,56 @@
+package quickselect
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+/*
+RowsTopK selects within every row of the matrix, like Float64QuickSelect does,
+so that the first k elements of each row are the k smallest elements of that
+row. It returns the selected block of every row, which is row[:k] and shares its
+backing array with the row.
+
+The rows are handed out to the given number of goroutines, which each take the
+next row that's left until there are none. A non-positive number of workers
+means runtime.GOMAXPROCS(0), and a single worker selects the rows one after
+another without starting any goroutines. Every row must hold at least k
+elements, which is checked before any of them is touched.
+*/
+func RowsTopK(matrix [][]float64, k, workers int) ([][]float64, error) {
+	for i, row := range matrix {
+		if k < 0 || k > len(row) {
+			return nil, fmt.Errorf("Row %d: %w", i, outOfRange(k, len(row)))
+		}
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(matrix))
+
+	topK := make([][]float64, len(matrix))
+	if workers <= 1 {
+		for i, row := range matrix {
+			orderedQuickSelect(row, k)
+			topK[i] = row[:k]
+		}
+		return topK, nil
+	}
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := int(next.Add(1)) - 1; i < len(matrix); i = int(next.Add(1)) - 1 {
+				orderedQuickSelect(matrix[i], k)
+				topK[i] = matrix[i][:k]
+			}
+		}()
+	}
+	wg.Wait()
+	return topK, nil
+}
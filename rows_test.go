@@ -0,0 +1,47 @@
+package quickselect
+
+import (
+	"math/rand/v2"
+	"sort"
+	"testing"
+)
+
+func TestRowsTopK(t *testing.T) {
+	for _, workers := range []int{0, 1, 3, 100} {
+		matrix := make([][]float64, 50)
+		sorted := make([][]float64, len(matrix))
+		for i := range matrix {
+			matrix[i] = make([]float64, 1000+i)
+			for j := range matrix[i] {
+				matrix[i][j] = rand.Float64()
+			}
+			sorted[i] = append([]float64(nil), matrix[i]...)
+			sort.Float64s(sorted[i])
+		}
+
+		topK, err := RowsTopK(matrix, 10, workers)
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if len(topK) != len(matrix) {
+			t.Fatalf("Expected %d rows, but got %d", len(matrix), len(topK))
+		}
+		for i := range topK {
+			if !hasSameElementsFloat64(topK[i], sorted[i][:10]) {
+				t.Errorf("Expected smallest K elements of row %d to be '%v', but got '%v' with %d workers", i, sorted[i][:10], topK[i], workers)
+			}
+		}
+	}
+
+	if topK, err := RowsTopK(nil, 3, 4); err != nil || len(topK) != 0 {
+		t.Errorf("Expected no rows for an empty matrix, but got '%v' and '%v'", topK, err)
+	}
+
+	matrix := [][]float64{{3, 1, 2}, {1}, {5, 4}}
+	if _, err := RowsTopK(matrix, 2, 1); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+	if matrix[0][0] != 3 || matrix[2][0] != 5 {
+		t.Errorf("Expected no row to be touched when one is too short, but got '%v'", matrix)
+	}
+}
@@ -0,0 +1,114 @@
+package quickselect
+
+import (
+	"math"
+	"sort"
+)
+
+/*
+A QuantileEstimator answers approximate quantile queries over a stream of
+float64s of unknown length, using the Greenwald-Khanna sketch. It is the online
+counterpart of Float64Quantile, for long running metrics collection where the
+values can't all be kept around to select from.
+
+For an error bound epsilon, the value returned for the q-th quantile of n values
+added so far has a rank within epsilon*n of q*n, while only O(1/epsilon *
+log(epsilon*n)) values are held. Like Float64Slice, NaNs are considered smaller
+than any other value. A QuantileEstimator is not safe for concurrent use.
+*/
+type QuantileEstimator struct {
+	epsilon float64
+	// period is the number of values added between compressions.
+	period int
+	n      int
+	tuples []gkTuple
+}
+
+// A gkTuple stands for g of the values added, the largest of which is v. The
+// rank of v is at least the sum of the g of it and all the tuples before it,
+// and at most delta more than that.
+type gkTuple struct {
+	v        float64
+	g, delta int
+}
+
+// minQuantileEpsilon is the smallest error bound a QuantileEstimator accepts.
+const minQuantileEpsilon = 1e-9
+
+// NewQuantileEstimator returns a QuantileEstimator with the given error bound,
+// which is clamped to the range [1e-9, 1]. Smaller bounds hold on to more
+// values.
+func NewQuantileEstimator(epsilon float64) *QuantileEstimator {
+	if !(epsilon >= minQuantileEpsilon) {
+		epsilon = minQuantileEpsilon
+	}
+	epsilon = min(epsilon, 1)
+	return &QuantileEstimator{epsilon: epsilon, period: max(int(1/(2*epsilon)), 1)}
+}
+
+// Add adds a value to the stream.
+func (e *QuantileEstimator) Add(v float64) {
+	i := sort.Search(len(e.tuples), func(i int) bool {
+		return lessOrdered(v, e.tuples[i].v)
+	})
+
+	delta := 0
+	if i > 0 && i < len(e.tuples) {
+		delta = e.slack()
+	}
+	e.tuples = append(e.tuples, gkTuple{})
+	copy(e.tuples[i+1:], e.tuples[i:])
+	e.tuples[i] = gkTuple{v, 1, delta}
+
+	e.n++
+	if e.n%e.period == 0 {
+		e.compress()
+	}
+}
+
+// Count returns the number of values added so far.
+func (e *QuantileEstimator) Count() int {
+	return e.n
+}
+
+/*
+Quantile returns an estimate of the q-th quantile of the values added so far,
+for q in the range [0, 1]. That is the value whose rank is ceil(q*n), like the
+"type 1" quantile WeightedQuantile computes, give or take epsilon*n ranks. NaN
+is returned if no values have been added, or if q is outside of [0, 1].
+*/
+func (e *QuantileEstimator) Quantile(q float64) float64 {
+	if !(q >= 0 && q <= 1) || e.n == 0 {
+		return math.NaN()
+	}
+
+	rank := max(math.Ceil(q*float64(e.n)), 1)
+	bound := rank + e.epsilon*float64(e.n)
+	minRank := 0
+	for i, t := range e.tuples {
+		minRank += t.g
+		if float64(minRank+t.delta) > bound {
+			return e.tuples[max(i-1, 0)].v
+		}
+	}
+	return e.tuples[len(e.tuples)-1].v
+}
+
+// Returns how far the rank of a newly added value may be from what the
+// tuples say, which is floor(2*epsilon*n).
+func (e *QuantileEstimator) slack() int {
+	return int(2 * e.epsilon * float64(e.n))
+}
+
+// Merges adjacent tuples whose combined uncertainty stays within the slack.
+// The first and last tuples are never merged away, so the minimum and maximum
+// are always known exactly.
+func (e *QuantileEstimator) compress() {
+	slack := e.slack()
+	for i := len(e.tuples) - 2; i >= 1; i-- {
+		if next := e.tuples[i+1]; e.tuples[i].g+next.g+next.delta <= slack {
+			e.tuples[i+1].g += e.tuples[i].g
+			e.tuples = append(e.tuples[:i], e.tuples[i+1:]...)
+		}
+	}
+}
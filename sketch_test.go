@@ -0,0 +1,76 @@
+package quickselect
+
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+	"testing"
+)
+
+func TestQuantileEstimator(t *testing.T) {
+	for _, epsilon := range []float64{0.1, 0.01, 0.001} {
+		e := NewQuantileEstimator(epsilon)
+		values := make([]float64, 100000)
+		for i := range values {
+			values[i] = rand.NormFloat64()
+			e.Add(values[i])
+		}
+		sort.Float64s(values)
+
+		if e.Count() != len(values) {
+			t.Errorf("Expected count %d, but got %d", len(values), e.Count())
+		}
+		if limit := int(10 / epsilon); len(e.tuples) > limit {
+			t.Errorf("Expected at most %d values to be held for epsilon %g, but got %d", limit, epsilon, len(e.tuples))
+		}
+
+		n := float64(len(values))
+		for _, q := range []float64{0, 0.001, 0.1, 0.25, 0.5, 0.75, 0.9, 0.999, 1} {
+			estimate := e.Quantile(q)
+			// The ranks the estimate may occupy among the values.
+			lo := sort.SearchFloat64s(values, estimate) + 1
+			hi := sort.Search(len(values), func(i int) bool { return values[i] > estimate })
+			rank := max(math.Ceil(q*n), 1)
+			if float64(hi) < rank-epsilon*n || float64(lo) > rank+epsilon*n {
+				t.Errorf("Expected the %g-th quantile's rank to be within %g of %g for epsilon %g, but got [%d,%d]", q, epsilon*n, rank, epsilon, lo, hi)
+			}
+		}
+	}
+}
+
+func TestQuantileEstimatorNaN(t *testing.T) {
+	e := NewQuantileEstimator(0.01)
+	if !math.IsNaN(e.Quantile(0.5)) {
+		t.Errorf("Expected NaN for an empty stream, but got %g", e.Quantile(0.5))
+	}
+
+	for i := 0; i < 1000; i++ {
+		if i%4 == 0 {
+			e.Add(math.NaN())
+		} else {
+			e.Add(float64(i))
+		}
+	}
+	if !math.IsNaN(e.Quantile(0.1)) {
+		t.Errorf("Expected NaNs to be the smallest values, but got %g", e.Quantile(0.1))
+	}
+	if f := e.Quantile(0.5); math.IsNaN(f) {
+		t.Errorf("Expected the median to be a number, but got %g", f)
+	}
+	if !math.IsNaN(e.Quantile(1.5)) || !math.IsNaN(e.Quantile(math.NaN())) {
+		t.Errorf("Expected NaN for a quantile outside of [0,1]")
+	}
+
+	fixtures := []struct{ Epsilon, Expected float64 }{
+		{0, minQuantileEpsilon},
+		{-1, minQuantileEpsilon},
+		{math.NaN(), minQuantileEpsilon},
+		{5, 1},
+		{0.05, 0.05},
+	}
+	for _, fixture := range fixtures {
+		if e := NewQuantileEstimator(fixture.Epsilon); e.epsilon != fixture.Expected {
+			t.Errorf("Expected epsilon %g to be clamped to %g, but got %g", fixture.Epsilon, fixture.Expected, e.epsilon)
+		}
+	}
+}
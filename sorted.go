@@ -0,0 +1,65 @@
+package quickselect
+
+import "sort"
+
+// maxUnsortedSteps is the number of descents, adjacent pairs that are out of
+// order, that data may have while still being considered nearly sorted.
+const maxUnsortedSteps = 5
+
+/*
+IsNearlySorted reports whether the data has at most five descents, that is
+adjacent pairs that are out of order. It only calls Len and Less, so the data is
+left untouched, and it stops as soon as it finds a sixth descent, so unsorted
+data is usually rejected after a few comparisons.
+
+Only descents are counted, not how far elements are from where they belong: a
+rotated slice such as [3, 4, 5, 0, 1, 2] has a single descent, and is reported
+as nearly sorted even though every element is misplaced. This lets pipelines
+that often receive presorted data cheaply tell it apart from the rest, and
+IsSorted tells whether it's sorted outright, in which case the smallest k
+elements are simply the first k and no selection is needed at all.
+*/
+func IsNearlySorted(data Interface) bool {
+	return unsortedSteps(data, maxUnsortedSteps+1) <= maxUnsortedSteps
+}
+
+// IsSorted reports whether the data is sorted in ascending order. It only calls
+// Len and Less, so the data is left untouched.
+func IsSorted(data Interface) bool {
+	return unsortedSteps(data, 1) == 0
+}
+
+// Counts the adjacent pairs of the data that are out of order, up to limit.
+func unsortedSteps(data Interface, limit int) int {
+	steps := 0
+	for i, length := 1, data.Len(); i < length && steps < limit; i++ {
+		if data.Less(i, i-1) {
+			steps++
+		}
+	}
+	return steps
+}
+
+/*
+SelectOrAll swaps elements in the data provided so that the first k elements
+are the smallest k elements, like QuickSelect, unless k is more than half of
+data.Len(), in which case it sorts all of the data instead and reports so.
+
+Past that crossover a selection still runs in O(n) time, but most callers go on
+to sort the k-block they get, which then costs O(k log k) = O(n log n) anyway.
+Sorting right away costs about the same as selecting and sorting the block,
+and orders the rest of the data for free. Below the crossover the block is
+left in no particular order, and sorted is false.
+*/
+func SelectOrAll(data Interface, k int) (sorted bool, err error) {
+	length := data.Len()
+	if k < 0 || k > length {
+		return false, outOfRange(k, length)
+	}
+
+	if k > length/2 {
+		sort.Sort(lessSwap{length, data.Less, data.Swap})
+		return true, nil
+	}
+	return false, new(selection).quickSelectN(data, k, length)
+}
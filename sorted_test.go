@@ -0,0 +1,64 @@
+package quickselect
+
+import "testing"
+
+func TestIsNearlySorted(t *testing.T) {
+	fixtures := []struct {
+		Array    []int
+		Sorted   bool
+		Nearly   bool
+		Compares int
+	}{
+		{[]int{}, true, true, 0},
+		{[]int{7}, true, true, 0},
+		{[]int{1, 2, 2, 3, 5, 8}, true, true, 5},
+		{[]int{2, 1, 3, 4, 5, 6}, false, true, 5},
+		{[]int{2, 1, 4, 3, 6, 5, 8, 7, 10, 9}, false, true, 9},
+		{[]int{2, 1, 4, 3, 6, 5, 8, 7, 10, 9, 12, 11}, false, false, 11},
+		{[]int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0, -1, -2}, false, false, 6},
+		// Only descents are counted, so a rotation is nearly sorted.
+		{[]int{3, 4, 5, 0, 1, 2}, false, true, 5},
+	}
+
+	for _, fixture := range fixtures {
+		data := &lessCounter{Interface: IntSlice(fixture.Array)}
+		if sorted := IsSorted(data); sorted != fixture.Sorted {
+			t.Errorf("Expected IsSorted to be %v for '%v', but got %v", fixture.Sorted, fixture.Array, sorted)
+		}
+
+		data.compares = 0
+		if nearly := IsNearlySorted(data); nearly != fixture.Nearly {
+			t.Errorf("Expected IsNearlySorted to be %v for '%v', but got %v", fixture.Nearly, fixture.Array, nearly)
+		}
+		if data.compares != fixture.Compares {
+			t.Errorf("Expected IsNearlySorted to make %d comparisons for '%v', but got %d", fixture.Compares, fixture.Array, data.compares)
+		}
+	}
+}
+
+func TestSelectOrAll(t *testing.T) {
+	for _, k := range []int{0, 1, 500, 501, 999, 1000} {
+		array := make(IntSlice, 1000)
+		for i := range array {
+			array[i] = (i * 7919) % 1000
+		}
+
+		sorted, err := SelectOrAll(array, k)
+		if err != nil {
+			t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		if sorted != (k > 500) {
+			t.Errorf("Expected sorted to be %v for k = %d, but got %v", k > 500, k, sorted)
+		}
+		if sorted && !IsSorted(array) {
+			t.Errorf("Expected the data to be sorted for k = %d", k)
+		}
+		if !hasSameElements(array[:k], rangeOfInts(k)) {
+			t.Errorf("Expected SelectOrAll to find the smallest %d elements", k)
+		}
+	}
+
+	if _, err := SelectOrAll(IntSlice{1, 2}, 3); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
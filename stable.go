@@ -0,0 +1,48 @@
+package quickselect
+
+/*
+StableSelect swaps elements in the data provided so that the first k elements
+are the smallest k elements, just like QuickSelect, but keeps those k elements
+in the same relative order they had in the input. In particular elements that
+are equal keep their original order, so ties are broken first come, first
+served. The order of the elements after the first k is unspecified.
+
+The selection runs on a separate array of indices, ordered by value and then by
+original index, so StableSelect needs O(n) extra space. The data is only
+reordered once the selection is done.
+*/
+func StableSelect(data Interface, k int) error {
+	return new(selection).stableSelect(data, k)
+}
+
+// Does the work for StableSelect, selecting on the indices with the selection's
+// settings.
+func (s *selection) stableSelect(data Interface, k int) error {
+	length := data.Len()
+	if k < 0 || k > length {
+		return outOfRange(k, length)
+	} else if k == 0 {
+		return nil
+	}
+
+	indices := make([]int, length)
+	for i := range indices {
+		indices[i] = i
+	}
+	err := s.quickSelect(lessSwap{
+		n: length,
+		less: func(i, j int) bool {
+			a, b := indices[i], indices[j]
+			return data.Less(a, b) || !data.Less(b, a) && a < b
+		},
+		swap: func(i, j int) {
+			indices[i], indices[j] = indices[j], indices[i]
+		},
+	}, k)
+	if err != nil {
+		return err
+	}
+
+	moveToFront(data, indices[:k])
+	return nil
+}
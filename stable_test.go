@@ -0,0 +1,72 @@
+package quickselect
+
+import (
+	"math/rand/v2"
+	"sort"
+	"testing"
+)
+
+type record struct {
+	key, seq int
+}
+
+type records []record
+
+func (r records) Len() int           { return len(r) }
+func (r records) Less(i, j int) bool { return r[i].key < r[j].key }
+func (r records) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+
+func TestStableSelect(t *testing.T) {
+	for _, size := range []int{1, 10, 100, 5000} {
+		for _, k := range []int{1, size / 3, size} {
+			if k < 1 {
+				continue
+			}
+
+			data := make(records, size)
+			for i := range data {
+				data[i] = record{rand.IntN(10), i}
+			}
+			expected := append(records(nil), data...)
+			sort.Stable(expected)
+			threshold := expected[k-1].key
+
+			if err := StableSelect(data, k); err != nil {
+				t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+			}
+
+			for i := 0; i < k; i++ {
+				if data[i].key > threshold {
+					t.Errorf("Expected element %d to be at most %d, but got %d", i, threshold, data[i].key)
+				}
+				if i > 0 && data[i-1].seq > data[i].seq {
+					t.Errorf("Expected selected elements to keep their input order, but got %v before %v", data[i-1], data[i])
+				}
+			}
+			for _, elem := range data[k:] {
+				if elem.key < threshold {
+					t.Errorf("Expected element after the block to be at least %d, but got %d", threshold, elem.key)
+				}
+			}
+		}
+	}
+}
+
+func TestStableSelectTies(t *testing.T) {
+	data := records{{3, 0}, {1, 1}, {2, 2}, {1, 3}, {2, 4}, {2, 5}, {0, 6}}
+	if err := StableSelect(data, 4); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+
+	expected := records{{1, 1}, {2, 2}, {1, 3}, {0, 6}}
+	for i := range expected {
+		if data[i] != expected[i] {
+			t.Errorf("Expected '%v', but got '%v'", expected, data[:4])
+			break
+		}
+	}
+
+	if err := StableSelect(data, 8); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
@@ -0,0 +1,44 @@
+package quickselect
+
+// Stats describes the work a selection did, as reported by SelectWithStats.
+type Stats struct {
+	// Comparisons is the number of calls made to Less.
+	Comparisons int
+	// Partitions is the number of times the randomized selection partitioned
+	// a range around a pivot. It's zero when the naive or heap strategy was
+	// picked instead.
+	Partitions int
+	// MaxDepth is the largest number of nested ranges the randomized
+	// selection narrowed down through before it was done. A depth much larger
+	// than the logarithm of the length means the pivots were unlucky.
+	MaxDepth int
+	// Fallback reports whether the randomized selection made so many
+	// unbalanced partitions that it handed the rest of the work to the
+	// fallback, see Options.
+	Fallback bool
+}
+
+// statsData counts the calls made to Less on the data it wraps.
+type statsData struct {
+	Interface
+	stats *Stats
+}
+
+func (t statsData) Less(i, j int) bool {
+	t.stats.Comparisons++
+	return t.Interface.Less(i, j)
+}
+
+/*
+SelectWithStats works like QuickSelect, and additionally reports what the
+selection went through. This is meant for diagnostics, such as spotting inputs
+that drive the selection down its slow path in production. Counting the
+comparisons adds an indirection to every call to Less, so it's somewhat slower
+than QuickSelect.
+*/
+func SelectWithStats(data Interface, k int) (Stats, error) {
+	var stats Stats
+	s := selection{stats: &stats}
+	err := s.quickSelect(statsData{data, &stats}, k)
+	return stats, err
+}
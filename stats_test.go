@@ -0,0 +1,55 @@
+package quickselect
+
+import (
+	"math/bits"
+	"sort"
+	"testing"
+)
+
+func TestSelectWithStats(t *testing.T) {
+	array := randomInts(100000, 50000, 5)
+	sorted := append([]int(nil), array...)
+	sort.Ints(sorted)
+
+	stats, err := SelectWithStats(IntSlice(array), 40000)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElements(array[:40000], sorted[:40000]) {
+		t.Errorf("Wrong smallest 40000 elements")
+	}
+	if stats.Comparisons < len(array) || stats.Partitions < 1 || stats.MaxDepth < 1 {
+		t.Errorf("Expected comparisons, partitions and depth to be counted, but got %+v", stats)
+	}
+	if stats.MaxDepth > 4*bits.Len(uint(len(array))) {
+		t.Errorf("Expected a depth of about log n, but got %d", stats.MaxDepth)
+	}
+
+	stats, err = SelectWithStats(IntSlice(sorted), 5)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if stats.Partitions != 0 || stats.Fallback {
+		t.Errorf("Expected no partitions for already selected data, but got %+v", stats)
+	}
+}
+
+func TestSelectWithStatsFallback(t *testing.T) {
+	data := make(IntSlice, 100000)
+	for i := range data {
+		data[i] = len(data) - i
+	}
+
+	var stats Stats
+	s := selection{limit: -1, stats: &stats}
+	if err := s.quickSelect(data, 50000); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !stats.Fallback || stats.Partitions != 0 {
+		t.Errorf("Expected the fallback to have fired right away, but got %+v", stats)
+	}
+
+	if _, err := SelectWithStats(data, len(data)+1); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
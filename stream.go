@@ -0,0 +1,101 @@
+package quickselect
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+/*
+Float64StreamTopK reads newline separated float64s from r and returns the k
+smallest of them, sorted in ascending order. Blank lines are skipped. Only k
+values are held in memory at any time, so the stream can be arbitrarily large.
+NaNs are treated as smaller than any other value, just like in Float64Slice.
+An error is raised if k is negative, before anything is read.
+*/
+func Float64StreamTopK(r io.Reader, k int) ([]float64, error) {
+	if k < 0 {
+		return nil, negativeK(k)
+	}
+	topK := NewTopK(k, lessOrdered[float64])
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Cannot parse line %d: %w", line, err)
+		}
+		topK.Push(f)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return topK.Items(), nil
+}
+
+/*
+Float64BinaryStreamTopK reads float64s encoded as 8 bytes each, in the given
+byte order, from r and returns the k smallest of them, sorted in ascending
+order. Like Float64StreamTopK it only ever holds k values in memory, and raises
+an error if k is negative.
+*/
+func Float64BinaryStreamTopK(r io.Reader, order binary.ByteOrder, k int) ([]float64, error) {
+	if k < 0 {
+		return nil, negativeK(k)
+	}
+	topK := NewTopK(k, lessOrdered[float64])
+
+	reader := bufio.NewReader(r)
+	var buf [8]byte
+	for {
+		if _, err := io.ReadFull(reader, buf[:]); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		topK.Push(math.Float64frombits(order.Uint64(buf[:])))
+	}
+
+	return topK.Items(), nil
+}
+
+/*
+Float64ChanTopK drains the channel and returns the k smallest values received,
+sorted in ascending order, once the channel is closed. Like Float64StreamTopK
+it only ever holds k values in memory, in a bounded max-heap, and treats NaNs
+as smaller than any other value.
+
+If the context is done before the channel is closed, Float64ChanTopK stops
+waiting for the producer and returns the k smallest values received so far
+along with ctx.Err(), so a stuck producer can't hang the consumer forever. An
+error is raised if k is negative, before anything is received.
+*/
+func Float64ChanTopK(ctx context.Context, ch <-chan float64, k int) ([]float64, error) {
+	if k < 0 {
+		return nil, negativeK(k)
+	}
+	topK := NewTopK(k, lessOrdered[float64])
+	for {
+		select {
+		case f, ok := <-ch:
+			if !ok {
+				return topK.Items(), nil
+			}
+			topK.Push(f)
+		case <-ctx.Done():
+			return topK.Items(), ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package quickselect
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFloat64StreamTopK(t *testing.T) {
+	input := "16.1\n29.3\n-11.5\n\n25.3\n28.8\n  -14.7\n10.5\n4.4\n7.5\n-27.9\n"
+	topK, err := Float64StreamTopK(strings.NewReader(input), 4)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+
+	expectedK := []float64{-27.9, -14.7, -11.5, 4.4}
+	if len(topK) != len(expectedK) {
+		t.Fatalf("Expected smallest K elements to be '%v', but got '%v'", expectedK, topK)
+	}
+	for i := range topK {
+		if topK[i] != expectedK[i] {
+			t.Errorf("Expected smallest K elements to be '%v', but got '%v'", expectedK, topK)
+			break
+		}
+	}
+
+	if _, err := Float64StreamTopK(strings.NewReader("1.5\nnope\n"), 1); err == nil {
+		t.Errorf("Should have raised error on unparseable line.")
+	}
+	if _, err := Float64StreamTopK(strings.NewReader(input), -1); !errors.Is(err, ErrKOutOfRange) {
+		t.Errorf("Should have raised error on negative k, but got '%v'", err)
+	}
+}
+
+func TestFloat64BinaryStreamTopK(t *testing.T) {
+	var buf bytes.Buffer
+	for _, f := range []float64{16.1, 29.3, -11.5, math.Inf(-1), 25.3, 4.4} {
+		binary.Write(&buf, binary.BigEndian, f)
+	}
+
+	topK, err := Float64BinaryStreamTopK(bytes.NewReader(buf.Bytes()), binary.BigEndian, 2)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if len(topK) != 2 || !math.IsInf(topK[0], -1) || topK[1] != -11.5 {
+		t.Errorf("Expected smallest K elements to be '%v', but got '%v'", []float64{math.Inf(-1), -11.5}, topK)
+	}
+
+	_, err = Float64BinaryStreamTopK(bytes.NewReader(buf.Bytes()[:13]), binary.BigEndian, 2)
+	if err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected '%v' on a truncated stream, but got '%v'", io.ErrUnexpectedEOF, err)
+	}
+
+	if _, err := Float64BinaryStreamTopK(bytes.NewReader(buf.Bytes()), binary.BigEndian, -2); !errors.Is(err, ErrKOutOfRange) {
+		t.Errorf("Should have raised error on negative k, but got '%v'", err)
+	}
+}
+
+func TestFloat64ChanTopK(t *testing.T) {
+	ch := make(chan float64)
+	go func() {
+		for _, f := range []float64{16.1, 29.3, -11.5, 25.3, 28.8, -14.7, 10.5, 4.4, 7.5, -27.9} {
+			ch <- f
+		}
+		close(ch)
+	}()
+
+	topK, err := Float64ChanTopK(context.Background(), ch, 4)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	expectedK := []float64{-27.9, -14.7, -11.5, 4.4}
+	if len(topK) != len(expectedK) {
+		t.Fatalf("Expected smallest K elements to be '%v', but got '%v'", expectedK, topK)
+	}
+	for i := range topK {
+		if topK[i] != expectedK[i] {
+			t.Errorf("Expected smallest K elements to be '%v', but got '%v'", expectedK, topK)
+			break
+		}
+	}
+
+	// A producer that sends a few values and then gets stuck without closing
+	// the channel.
+	stuck := make(chan float64, 3)
+	stuck <- 3
+	stuck <- 1
+	stuck <- 2
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	topK, err = Float64ChanTopK(ctx, stuck, 2)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected '%v' for a stuck producer, but got '%v'", context.DeadlineExceeded, err)
+	}
+	if len(topK) != 2 || topK[0] != 1 || topK[1] != 2 {
+		t.Errorf("Expected the smallest values received so far to be '%v', but got '%v'", []float64{1, 2}, topK)
+	}
+
+	stuck <- 4
+	if _, err := Float64ChanTopK(context.Background(), stuck, -1); !errors.Is(err, ErrKOutOfRange) {
+		t.Errorf("Should have raised error on negative k, but got '%v'", err)
+	}
+	if len(stuck) != 1 {
+		t.Errorf("Expected nothing to be received for a negative k")
+	}
+}
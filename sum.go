@@ -0,0 +1,123 @@
+package quickselect
+
+import "math"
+
+/*
+Float64SelectAndSum mutates the data so that the first k elements in the
+float64 slice are the k smallest elements in the slice, just like
+Float64QuickSelect, and returns the block they make up, [lo, hi) = [0, k),
+along with their sum. The sum is accumulated while the block is being
+partitioned, sparing a second pass over it, and is compensated (Neumaier
+summation). NaNs are selected first, as in Float64Slice. It panics if k is
+outside of the range [0, len(data)].
+*/
+func Float64SelectAndSum(data []float64, k int) (lo, hi int, sum float64) {
+	length := len(data)
+	if k < 0 || k > length {
+		panic(outOfRange(k, length))
+	}
+
+	// The partitioning adds up the elements it moves left of the pivot, and
+	// keeps that sum whenever the selection carries on to the right of it.
+	// Only the last few elements, insertion sorted while in cache, and data
+	// that's already selected are summed on their own. Compensation bounds the
+	// error by about 2ε|sum| + O(kε²)·Σ|x| rather than (k-1)ε·Σ|x|, and makes
+	// the result barely depend on the random order the elements come in.
+	block := new(blockSum)
+	if k == 0 || k == length || orderedIsSelected(data, k) {
+		block.total.addAll(data[:k])
+	} else {
+		orderedNarrowDown(data, 0, length-1, k, block)
+	}
+	return 0, k, block.total.value()
+}
+
+// blockSum is a blockAccumulator that sums the block.
+type blockSum struct {
+	total neumaierSum
+	// The sum of the elements left of the pivot of the last partition, the
+	// pivot and where it ended up, and whether all of the elements left of it
+	// are equal to it.
+	left       neumaierSum
+	pivot      float64
+	pivotIndex int
+	equal      bool
+}
+
+func (b *blockSum) partition(data []float64, low, high, pivotIndex int, equal bool) int {
+	b.left, b.equal = neumaierSum{}, equal
+	if equal {
+		// The elements moved left are all equal to the pivot, so there's
+		// nothing to add up until it's known how many of them are settled.
+		b.pivotIndex = orderedPartitionEqual(data, low, high, pivotIndex)
+		b.pivot = data[low]
+		return b.pivotIndex
+	}
+
+	// Mirrors orderedPartition.
+	partitionIndex := low
+	data[pivotIndex], data[high] = data[high], data[pivotIndex]
+	pivot := data[high]
+	for i := low; i < high; i++ {
+		if lessOrdered(data[i], pivot) {
+			b.left.add(data[i])
+			data[i], data[partitionIndex] = data[partitionIndex], data[i]
+			partitionIndex++
+		}
+	}
+	data[partitionIndex], data[high] = data[high], data[partitionIndex]
+	b.pivot, b.pivotIndex = pivot, partitionIndex
+	return partitionIndex
+}
+
+func (b *blockSum) settle(data []float64, low, end int, partitioned bool) {
+	switch {
+	case !partitioned:
+		b.total.addAll(data[low:end])
+	case b.equal:
+		b.total.add(b.pivot * float64(end-low))
+	default:
+		// Either the elements left of the pivot, or those and the pivot.
+		b.total.merge(b.left)
+		if end > b.pivotIndex {
+			b.total.add(b.pivot)
+		}
+	}
+}
+
+// neumaierSum accumulates a sum of float64s along with a compensation term for
+// the low order bits that were lost when rounding it.
+type neumaierSum struct {
+	sum, compensation float64
+}
+
+func (s *neumaierSum) add(f float64) {
+	t := s.sum + f
+	if math.Abs(s.sum) >= math.Abs(f) {
+		s.compensation += (s.sum - t) + f
+	} else {
+		s.compensation += (f - t) + s.sum
+	}
+	s.sum = t
+}
+
+// Adds another compensated sum to this one.
+func (s *neumaierSum) merge(other neumaierSum) {
+	s.add(other.sum)
+	s.compensation += other.compensation
+}
+
+func (s *neumaierSum) addAll(data []float64) {
+	for _, f := range data {
+		s.add(f)
+	}
+}
+
+// Returns the compensated sum. Once the sum is infinite the compensation is
+// meaningless, and may well be NaN, so it's left out.
+func (s *neumaierSum) value() float64 {
+	if math.IsInf(s.sum, 0) {
+		return s.sum
+	}
+	return s.sum + s.compensation
+}
@@ -0,0 +1,88 @@
+package quickselect
+
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+	"testing"
+)
+
+func TestFloat64SelectAndSum(t *testing.T) {
+	random := make([]float64, 2000)
+	for i := range random {
+		random[i] = float64(rand.IntN(500)) / 4
+	}
+	duplicates := make([]float64, 1000)
+	for i := range duplicates {
+		duplicates[i] = float64(i % 3)
+	}
+
+	fixtures := []struct {
+		Array []float64
+		Ks    []int
+	}{
+		{[]float64{}, []int{0}},
+		{[]float64{3.5}, []int{0, 1}},
+		{[]float64{2, 8, -1.5, 4, 0.25, 9, 3, 7}, []int{0, 1, 3, 5, 8}},
+		{random, []int{0, 1, 50, 999, 1000, 1999, 2000}},
+		{duplicates, []int{1, 333, 334, 500, 667, 999}},
+	}
+
+	for _, fixture := range fixtures {
+		sorted := append([]float64(nil), fixture.Array...)
+		sort.Float64s(sorted)
+		for _, k := range fixture.Ks {
+			data := append([]float64(nil), fixture.Array...)
+			lo, hi, total := Float64SelectAndSum(data, k)
+			if lo != 0 || hi != k {
+				t.Errorf("Expected block bounds [0,%d), but got [%d,%d)", k, lo, hi)
+			}
+			if !hasSameElementsFloat64(data[:k], sorted[:k]) {
+				t.Errorf("Expected smallest %d elements to be '%v', but got '%v'", k, sorted[:k], data[:k])
+			}
+			// The elements are multiples of a quarter, so any order sums exactly.
+			if expected := sum(sorted[:k]); total != expected {
+				t.Errorf("Expected the sum of the smallest %d elements to be %g, but got %g", k, expected, total)
+			}
+		}
+	}
+}
+
+func TestFloat64SelectAndSumCompensation(t *testing.T) {
+	// Large enough to be partitioned, with the ones only surviving the
+	// cancellation of the huge elements if the sum is compensated.
+	cancelling := []float64{1e100, -1e100}
+	for i := 0; i < 500; i++ {
+		cancelling = append(cancelling, 1, 2e100)
+	}
+
+	fixtures := []struct {
+		Array    []float64
+		K        int
+		Expected float64
+	}{
+		{[]float64{1, 1e100, 1, -1e100, 5e100}, 4, 2},
+		{cancelling, 502, 500},
+		{[]float64{0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 1}, 10, 1},
+		{[]float64{1, math.Inf(1), 2, 3}, 4, math.Inf(1)},
+		{[]float64{math.MaxFloat64, math.MaxFloat64, 1}, 3, math.Inf(1)},
+		{[]float64{1, math.NaN(), 2, 3}, 2, math.NaN()},
+	}
+
+	for _, fixture := range fixtures {
+		data := append([]float64(nil), fixture.Array...)
+		_, _, sum := Float64SelectAndSum(data, fixture.K)
+		if sum != fixture.Expected && !(math.IsNaN(sum) && math.IsNaN(fixture.Expected)) {
+			t.Errorf("Expected the sum of the smallest %d elements of '%v' to be %g, but got %g", fixture.K, fixture.Array, fixture.Expected, sum)
+		}
+	}
+}
+
+func TestFloat64SelectAndSumOutOfRange(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Should have raised error on index outside of array length.")
+		}
+	}()
+	Float64SelectAndSum([]float64{1, 2, 3}, 4)
+}
@@ -0,0 +1,88 @@
+package quickselect
+
+import "sort"
+
+/*
+SelectMinSwaps swaps elements in the data provided so that the first k elements
+are the smallest k elements, just like QuickSelect, while calling Swap as few
+times as possible. The selection runs on a separate array of indices, and only
+once it's done are the chosen elements swapped to the front, one Swap each at
+most. The elements that are neither among the first k nor chosen are left
+untouched.
+
+This makes for a guarantee on writes as well: the only positions ever written
+to are the first k and the ones the chosen elements are swapped out of, and any
+of those already holding its final element isn't written to at all. Data backed
+by memory mapped or copy-on-write pages thus only has those pages dirtied,
+rather than all of them as with QuickSelect. Since Swap is the only way to move
+elements, an element chosen from beyond the first k can't be moved without
+writing to its old position.
+
+Which mode to pick depends on what's expensive. QuickSelect makes O(n) calls to
+both Less and Swap, SelectMinSwaps makes the same O(n) calls to Less but at most
+k calls to Swap, at the price of O(n) extra space for the indices. When the
+elements are large structs whose Swap dominates, SelectMinSwaps is the faster
+of the two; when Less dominates, such as for long strings sharing prefixes,
+they're on par and QuickSelect saves the allocation. HeapSelect also makes at
+most k calls to Swap with only O(k) extra space, but O(n log k) calls to Less.
+*/
+func SelectMinSwaps(data Interface, k int) error {
+	length := data.Len()
+	if k < 0 || k > length {
+		return outOfRange(k, length)
+	} else if k == 0 {
+		return nil
+	}
+
+	indices := make([]int, length)
+	for i := range indices {
+		indices[i] = i
+	}
+	err := QuickSelect(lessSwap{
+		n: length,
+		less: func(i, j int) bool {
+			return data.Less(indices[i], indices[j])
+		},
+		swap: func(i, j int) {
+			indices[i], indices[j] = indices[j], indices[i]
+		},
+	}, k)
+	if err != nil {
+		return err
+	}
+
+	moveToFront(data, indices[:k])
+	return nil
+}
+
+/*
+Swaps the elements at the given indices to the front of the data, keeping their
+relative order. Doing so in ascending order of the indices never disturbs an
+element that's yet to be moved, since every position written to so far is
+either before the current one or was already moved. Elements already in place
+aren't swapped, so at most len(indices) calls to Swap are made.
+*/
+func moveToFront(data Interface, indices []int) {
+	sort.Ints(indices)
+	for i, j := range indices {
+		if i != j {
+			data.Swap(i, j)
+		}
+	}
+}
+
+/*
+SelectPreserveOrder swaps elements in the data provided so that the first k
+elements are the smallest k elements, and appear in the same relative order as
+they did in the input rather than in any order by value. This is what showing
+"the first 10 matching rows" needs, when rows are chosen by score but displayed
+in the order of the source. Unlike StableSelect, which of several tied elements
+make it into the block is left unspecified.
+
+The selection is that of SelectMinSwaps, which runs on a separate array of
+indices and moves the chosen elements to the front in ascending order of their
+original indices, so the order comes at no extra cost.
+*/
+func SelectPreserveOrder(data Interface, k int) error {
+	return SelectMinSwaps(data, k)
+}
@@ -0,0 +1,126 @@
+package quickselect
+
+import (
+	"math/rand/v2"
+	"sort"
+	"testing"
+)
+
+func TestSelectMinSwaps(t *testing.T) {
+	for _, size := range []int{1, 10, 1000, 100000} {
+		for _, k := range []int{0, 1, size / 2, size} {
+			array := make([]int, size)
+			for i := range array {
+				array[i] = rand.IntN(size)
+			}
+			original := append([]int(nil), array...)
+			sorted := append([]int(nil), array...)
+			sort.Ints(sorted)
+
+			data := &swapCounter{Interface: IntSlice(array)}
+			if err := SelectMinSwaps(data, k); err != nil {
+				t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+			}
+			if !hasSameElements(array[:k], sorted[:k]) {
+				t.Errorf("Wrong smallest %d elements of %d", k, size)
+			}
+			if data.swaps > k {
+				t.Errorf("Expected at most %d swaps, but got %d", k, data.swaps)
+			}
+
+			// Only the positions the chosen elements were swapped out of may
+			// have changed after the block.
+			changed := 0
+			for i := k; i < size; i++ {
+				if array[i] != original[i] {
+					changed++
+				}
+			}
+			if changed > k {
+				t.Errorf("Expected at most %d elements after the block to change, but got %d", k, changed)
+			}
+		}
+	}
+
+	if err := SelectMinSwaps(IntSlice{1, 2}, 3); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
+
+// writeTracker records every position written to by Swap.
+type writeTracker struct {
+	Interface
+	written map[int]int
+}
+
+func (w writeTracker) Swap(i, j int) {
+	w.written[i]++
+	w.written[j]++
+	w.Interface.Swap(i, j)
+}
+
+func TestSelectMinSwapsWrites(t *testing.T) {
+	array := make(IntSlice, 100000)
+	for i := range array {
+		array[i] = rand.IntN(1e6)
+	}
+	original := append([]int(nil), array...)
+
+	k := 100
+	data := writeTracker{array, make(map[int]int)}
+	if err := SelectMinSwaps(data, k); err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+
+	// Positions after the block may only be written to once, when the
+	// element chosen from there is swapped into the block.
+	chosen := make(map[int]bool)
+	for _, elem := range array[:k] {
+		chosen[elem] = true
+	}
+	writes := 0
+	for i, count := range data.written {
+		writes += count
+		if i >= k && (count != 1 || !chosen[original[i]]) {
+			t.Errorf("Expected position %d to only be written to for moving a chosen element, but got %d writes", i, count)
+		}
+	}
+	if writes > 2*k {
+		t.Errorf("Expected at most %d writes, but got %d", 2*k, writes)
+	}
+}
+
+func TestSelectPreserveOrder(t *testing.T) {
+	for _, size := range []int{1, 10, 1000, 100000} {
+		for _, k := range []int{0, 1, size / 3, size} {
+			data := make(records, size)
+			for i := range data {
+				data[i] = record{key: rand.IntN(size), seq: i}
+			}
+			keys := make([]int, size)
+			for i := range data {
+				keys[i] = data[i].key
+			}
+			sort.Ints(keys)
+
+			if err := SelectPreserveOrder(data, k); err != nil {
+				t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+			}
+			selected := make([]int, k)
+			for i := range selected {
+				selected[i] = data[i].key
+				if i > 0 && data[i].seq < data[i-1].seq {
+					t.Errorf("Expected the smallest %d of %d elements to keep their input order, but got %v before %v", k, size, data[i-1], data[i])
+					break
+				}
+			}
+			if !hasSameElements(selected, keys[:k]) {
+				t.Errorf("Wrong smallest %d elements of %d", k, size)
+			}
+		}
+	}
+
+	if err := SelectPreserveOrder(records{}, 1); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
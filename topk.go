@@ -0,0 +1,114 @@
+package quickselect
+
+import "sort"
+
+/*
+A TopK keeps track of the k smallest values pushed to it so far, without
+holding on to any of the others. It is the online counterpart of QuickSelect,
+for when values arrive one at a time (e.g. from a channel or a log file) and
+buffering all of them isn't an option.
+
+Internally it is a max-heap of at most k values, so every Push takes at most
+O(log k) time. A TopK is not safe for concurrent use.
+*/
+type TopK[T any] struct {
+	k     int
+	less  func(a, b T) bool
+	items []T
+	heap  []int
+}
+
+// NewTopK returns a TopK which keeps the k smallest values pushed to it, as
+// ordered by less.
+func NewTopK[T any](k int, less func(a, b T) bool) *TopK[T] {
+	k = max(k, 0)
+	return &TopK[T]{
+		k:     k,
+		less:  less,
+		items: make([]T, 0, k),
+		heap:  make([]int, 0, k),
+	}
+}
+
+// Push offers a value to the TopK, which keeps it if it's among the k smallest
+// values seen so far.
+func (t *TopK[T]) Push(v T) {
+	if len(t.items) < t.k {
+		t.heap = append(t.heap, len(t.items))
+		t.items = append(t.items, v)
+		if len(t.items) == t.k {
+			heapInit(topKHeap[T]{t}, t.heap)
+		}
+		return
+	}
+
+	if t.k > 0 && t.less(v, t.items[t.heap[0]]) {
+		t.items[t.heap[0]] = v
+		heapDown(topKHeap[T]{t}, t.heap, 0, t.k)
+	}
+}
+
+// Len returns the number of values currently held, which is at most k.
+func (t *TopK[T]) Len() int {
+	return len(t.items)
+}
+
+// Items returns a newly allocated slice with the values held, sorted in
+// ascending order.
+func (t *TopK[T]) Items() []T {
+	items := append([]T(nil), t.items...)
+	sort.Slice(items, func(i, j int) bool {
+		return t.less(items[i], items[j])
+	})
+	return items
+}
+
+// topKHeap lets the heap functions shared with heapSelectionFinding compare
+// the values held by a TopK.
+type topKHeap[T any] struct {
+	*TopK[T]
+}
+
+func (h topKHeap[T]) Len() int {
+	return len(h.items)
+}
+
+func (h topKHeap[T]) Less(i, j int) bool {
+	return h.less(h.items[i], h.items[j])
+}
+
+func (h topKHeap[T]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+/*
+MergeTopK merges the local smallest k elements of several chunks of data, as
+found by separate workers, into a newly allocated slice holding the global
+smallest k. Since each of the global smallest k is among the smallest k of its
+own chunk, only the M*k elements of the M chunks need to be looked at: they're
+concatenated and selected on once, in expected O(M*k) time. The chunks are left
+untouched and the returned elements are in no particular order.
+
+Each chunk should hold at most k elements. An error is raised if k exceeds the
+total number of elements in the chunks.
+*/
+func MergeTopK(chunks [][]int, k int) ([]int, error) {
+	total := 0
+	for _, chunk := range chunks {
+		total += len(chunk)
+	}
+	if k < 0 || k > total {
+		return nil, outOfRange(k, total)
+	} else if k == 0 {
+		return nil, nil
+	}
+
+	merged := make([]int, 0, total)
+	for _, chunk := range chunks {
+		merged = append(merged, chunk...)
+	}
+	if err := orderedQuickSelect(merged, k); err != nil {
+		return nil, err
+	}
+	return merged[:k:k], nil
+}
@@ -0,0 +1,89 @@
+package quickselect
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestTopK(t *testing.T) {
+	fixtures := []struct {
+		Values    []int
+		K         int
+		ExpectedK []int
+	}{
+		{[]int{0, 14, 16, 29, 12, 2, 4, 4, 7, 29}, 4, []int{0, 2, 4, 4}},
+		{[]int{16, 29, -11, 25, 28, -14, 10, 4, 7, -27}, 3, []int{-27, -14, -11}},
+		{[]int{9, 3}, 4, []int{3, 9}},
+		{[]int{9, 3, 2, 18}, 0, []int{}},
+		{[]int{}, 2, []int{}},
+	}
+
+	for _, fixture := range fixtures {
+		topK := NewTopK(fixture.K, func(a, b int) bool { return a < b })
+		for _, v := range fixture.Values {
+			topK.Push(v)
+		}
+
+		items := topK.Items()
+		if len(items) != len(fixture.ExpectedK) || topK.Len() != len(items) {
+			t.Errorf("Expected smallest K elements to be '%v', but got '%v'", fixture.ExpectedK, items)
+			continue
+		}
+		for i := range items {
+			if items[i] != fixture.ExpectedK[i] {
+				t.Errorf("Expected smallest K elements to be '%v', but got '%v'", fixture.ExpectedK, items)
+				break
+			}
+		}
+	}
+}
+
+func TestTopKMatchesSorting(t *testing.T) {
+	values := randomInts(10000, 5000, 1)
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+
+	topK := NewTopK(100, func(a, b int) bool { return a < b })
+	for _, v := range values {
+		topK.Push(v)
+	}
+
+	items := topK.Items()
+	for i := range items {
+		if items[i] != sorted[i] {
+			t.Errorf("Expected smallest K elements to be '%v', but got '%v'", sorted[:100], items)
+			break
+		}
+	}
+}
+
+func TestMergeTopK(t *testing.T) {
+	data := randomInts(10000, 5000, 3)
+	sorted := append([]int(nil), data...)
+	sort.Ints(sorted)
+
+	k := 50
+	var chunks [][]int
+	for lo := 0; lo < len(data); lo += 1500 {
+		chunk, err := IntSmallestK(data[lo:min(lo+1500, len(data))], k)
+		if err != nil {
+			t.Fatalf("Shouldn't have raised error: '%s'", err.Error())
+		}
+		chunks = append(chunks, chunk)
+	}
+
+	merged, err := MergeTopK(chunks, k)
+	if err != nil {
+		t.Errorf("Shouldn't have raised error: '%s'", err.Error())
+	}
+	if !hasSameElements(merged, sorted[:k]) {
+		t.Errorf("Expected merged smallest K elements to be '%v', but got '%v'", sorted[:k], merged)
+	}
+
+	if merged, err := MergeTopK([][]int{{3, 1}, {}, {2}}, 3); err != nil || !hasSameElements(merged, []int{1, 2, 3}) {
+		t.Errorf("Expected merged smallest K elements to be '%v', but got '%v'", []int{1, 2, 3}, merged)
+	}
+	if _, err := MergeTopK([][]int{{3, 1}, {2}}, 4); err == nil {
+		t.Errorf("Should have raised error on index outside of array length.")
+	}
+}
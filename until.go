@@ -0,0 +1,96 @@
+package quickselect
+
+import (
+	"math/rand/v2"
+	"sort"
+)
+
+/*
+SelectUntilSum returns the indices of the longest run of the smallest elements
+of the data whose sum doesn't exceed the limit, sorted so that the smallest
+element comes first. That is the greedy packing of the cheapest items into a
+budget: sorting the data ascending and keeping the longest prefix whose
+cumulative sum is at most limit gives the same elements.
+
+Negative elements lower the sum, so they're all taken first, and nothing is
+returned if even their sum exceeds the limit. NaNs are never taken. The data is
+left untouched.
+
+Rather than sorting all of the data, the indices are partitioned around random
+pivots, descending only into the side that the limit falls in, just like
+WeightedQuantile does. Only the k indices that are returned get sorted, so this
+runs in expected O(n + k log k) time.
+*/
+func SelectUntilSum(data []float64, limit float64) []int {
+	indices := make([]int, 0, len(data))
+	negatives, below := 0, 0.0
+	for i, f := range data {
+		if isNaN(f) {
+			continue
+		}
+		indices = append(indices, i)
+		if f < 0 {
+			indices[negatives], indices[len(indices)-1] = indices[len(indices)-1], indices[negatives]
+			negatives++
+			below += f
+		}
+	}
+	if below > limit {
+		return nil
+	}
+
+	values := lessSwap{
+		n: len(indices),
+		less: func(i, j int) bool {
+			return data[indices[i]] < data[indices[j]]
+		},
+		swap: func(i, j int) {
+			indices[i], indices[j] = indices[j], indices[i]
+		},
+	}
+
+	// All of the elements in [negatives, end) fit under the limit.
+	end := negatives
+	for low, high := negatives, len(indices)-1; low <= high; {
+		lt, gt := Partition(values, low, high, rand.IntN(high+1-low)+low)
+
+		less := 0.0
+		for _, i := range indices[low:lt] {
+			less += data[i]
+		}
+		if below+less > limit {
+			high = lt - 1
+			continue
+		}
+
+		below += less
+		end = lt
+		pivot := data[indices[lt]]
+		for end <= gt && below+pivot <= limit {
+			below += pivot
+			end++
+		}
+		if end <= gt {
+			break
+		}
+		low = gt + 1
+	}
+
+	selected := indices[:end]
+	sort.Slice(selected, func(i, j int) bool {
+		return data[selected[i]] < data[selected[j]]
+	})
+
+	// Summing in ascending order may round differently than summing group by
+	// group did, so the cut is settled on the sorted prefix.
+	total, cut := 0.0, 0
+	for i, index := range selected {
+		if total += data[index]; total <= limit {
+			cut = i + 1
+		}
+	}
+	if cut == 0 {
+		return nil
+	}
+	return selected[:cut:cut]
+}
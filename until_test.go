@@ -0,0 +1,77 @@
+package quickselect
+
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+	"testing"
+)
+
+func TestSelectUntilSum(t *testing.T) {
+	fixtures := []struct {
+		Data     []float64
+		Cap      float64
+		Expected []int
+	}{
+		{[]float64{5, 1, 4, 2, 3}, 6, []int{1, 3, 4}},
+		{[]float64{5, 1, 4, 2, 3}, 5.5, []int{1, 3}},
+		{[]float64{5, 1, 4, 2, 3}, 15, []int{1, 3, 4, 2, 0}},
+		{[]float64{5, 1, 4, 2, 3}, 0.5, nil},
+		{[]float64{2, 2, 2, 2}, 5, []int{0, 1}},
+		{[]float64{3, -4, math.NaN(), 1, -1}, 0, []int{1, 4, 3, 0}},
+		{[]float64{3, -4, math.NaN(), 1, -1}, -5, []int{1, 4}},
+		{[]float64{3, -4, 1, -1}, -6, nil},
+		{nil, 10, nil},
+	}
+
+	for _, fixture := range fixtures {
+		indices := SelectUntilSum(fixture.Data, fixture.Cap)
+		if len(indices) != len(fixture.Expected) {
+			t.Errorf("Expected indices '%v' for cap %g, but got '%v'", fixture.Expected, fixture.Cap, indices)
+			continue
+		}
+		for i := range indices {
+			// Equal values may come in either order.
+			if fixture.Data[indices[i]] != fixture.Data[fixture.Expected[i]] {
+				t.Errorf("Expected indices '%v' for cap %g, but got '%v'", fixture.Expected, fixture.Cap, indices)
+				break
+			}
+		}
+	}
+}
+
+func TestSelectUntilSumRandom(t *testing.T) {
+	data := make([]float64, 10000)
+	for i := range data {
+		data[i] = float64(rand.IntN(100))
+	}
+	original := append([]float64(nil), data...)
+	sorted := append([]float64(nil), data...)
+	sort.Float64s(sorted)
+
+	for _, limit := range []float64{0, 1, 100, 5000, 100000, 1e9} {
+		expected, total := 0, 0.0
+		for expected < len(sorted) && total+sorted[expected] <= limit {
+			total += sorted[expected]
+			expected++
+		}
+
+		indices := SelectUntilSum(data, limit)
+		if len(indices) != expected {
+			t.Errorf("Expected %d indices for cap %g, but got %d", expected, limit, len(indices))
+			continue
+		}
+		for i, index := range indices {
+			if data[index] != sorted[i] {
+				t.Errorf("Expected the smallest elements in ascending order for cap %g", limit)
+				break
+			}
+		}
+	}
+	for i := range data {
+		if data[i] != original[i] {
+			t.Errorf("Expected data to be left untouched")
+			break
+		}
+	}
+}